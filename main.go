@@ -1,22 +1,79 @@
 package main
 
 import (
+	"embed"
 	"flag"
 	_ "image/jpeg"
 	_ "image/png"
+	"io/fs"
 	"log"
+	"path/filepath"
+	"time"
 
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/controller"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+// Assets embedded in the binary, so it can be run from any directory
+//
+//go:embed assets/audio assets/levels assets/skins assets/difficulties.json
+var embeddedAssets embed.FS
+
 var gameController *controller.GameController
 
 func init() {
 	nEnemies := flag.Int("n", 1, "Number of enemies to go against")
+	difficulty := flag.String("d", constants.DefaultDifficulty, "Difficulty preset (easy, normal, hard or arcade)")
+	mode := flag.String("mode", constants.DefaultGameMode, "Game mode (classic, endless, timeattack or survival)")
+	soundPack := flag.String("sounds", "", "Sound pack in assets/audio to use from now on")
+	audioBackend := flag.String("audio", constants.EbitenAudio, "Audio backend (ebiten or null)")
+	record := flag.Bool("record", false, "Log every sound effect played along with when it happened")
+	skin := flag.String("skin", constants.DefaultSkin, "Skin in assets/skins to draw the game with")
+	adaptive := flag.Bool("adaptive", false, "Adapt the difficulty to the performance of the player")
+	levelFile := flag.String("l", constants.DefaultLevelFile, "Level file to play")
+	generate := flag.Bool("generate", false, "Play a randomly generated maze instead of the level file")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "Seed used to generate the maze")
+	assetsDir := flag.String("assets", "", "Directory with assets that override the embedded ones")
+	dataDir := flag.String("data", "", "Directory to keep the high scores, audio settings and edited level in")
 	flag.Parse()
-	var err error
-	gameController, err = controller.InitGameController(*nEnemies)
+	embedded, err := fs.Sub(embeddedAssets, "assets")
+	if err != nil {
+		log.Fatal(err)
+	}
+	assets, err := modules.InitAssetFS(embedded, *assetsDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Level files given explicitly are read from disk rather than from the assets
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name != "l" {
+			return
+		}
+		if *levelFile, err = filepath.Abs(*levelFile); err != nil {
+			log.Fatal(err)
+		}
+	})
+	if *generate {
+		*levelFile, err = controller.GenerateLevelFile(*seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	gameController, err = controller.InitGameController(
+		assets,
+		*nEnemies,
+		*levelFile,
+		*dataDir,
+		*difficulty,
+		*mode,
+		*soundPack,
+		*audioBackend,
+		*skin,
+		*adaptive,
+		*record,
+	)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -30,6 +87,7 @@ func (g *Game) Update() error {
 	if !gameController.IsActive() {
 		gameController.InitGame()
 	}
+	gameController.Update()
 	return nil
 }
 
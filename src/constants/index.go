@@ -1,32 +1,78 @@
 package constants
 
-// Standard constants used in the codebase
+// Standard constants used in the codebase, with the paths of assets relative to the assets directory
+// and the files of the player relative to the data directory
 const (
-	HorizontalTiles    = 27
-	VerticalTiles      = 23
-	TileSize           = 32
-	MaxGhostsAllowed   = 8
-	InfiniteChasePhase = 3
-	TimeBetweenSpawns  = 3
+	HorizontalTiles        = 27
+	VerticalTiles          = 23
+	TileSize               = 32
+	MaxGhostsAllowed       = 8
+	MinTimeBetweenReleases = 1
+	DefaultDifficulty      = "normal"
+	DifficultiesFile       = "difficulties.json"
+	LevelsDir              = "levels"
+	DefaultLevelFile       = "levels/level1.txt"
+	EditorLevelFile        = "custom-level.txt"
+	DefaultGameMode        = "classic"
+	DataDirName            = "MultithreadedPacman"
+	HighScoresFile         = "highscores.json"
+	AudioSettingsFile      = "audio-settings.json"
+	SoundPacksDir          = "audio"
+	SoundPackManifest      = "manifest.json"
+	DefaultSoundPack       = "default"
+	SkinsDir               = "skins"
+	SkinManifest           = "manifest.json"
+	DefaultSkin            = "default"
+	MaxHighScores          = 10
 )
 
-// Speed constants
+// Time attack constants in seconds
 const (
-	DefaultPacmanFPS = 6
-	PowerPacmanFPS   = 8
-	DefaultGhostFPS  = 6
-	FleeingGhostFPS  = 4
-	EatenGhostFPS    = 12
+	TimeAttackDuration   = 120
+	GhostEatenTimeBonus  = 5
+	PowerPelletTimeBonus = 3
 )
 
-// Fixed duration of phases
+// Survival constants, with intervals in seconds
 const (
-	ScatterModeDuration     = 7
-	ChaseModeDuration       = 20
-	FlickeringStateDuration = 2
-	PowerPelletDuration     = 7
+	SurvivalWaveInterval    = 20
+	PelletRespawnInterval   = 30
+	SurvivalPointsPerSecond = 10
+	MaxSurvivalGhosts       = 32
 )
 
+// Adaptive difficulty constants. The director evaluates the player every DirectorInterval
+// seconds and adjusts the ghosts up to the given fraction of their original settings
+const (
+	DirectorInterval        = 5
+	FastPelletInterval      = 0.5
+	SlowPelletInterval      = 2
+	MaxGhostSpeedAdjustment = 0.1
+	MaxFrightenedAdjustment = 0.4
+	MaxRandomTurnAdjustment = 0.15
+)
+
+// Movement constants. Movable objects are updated TicksPerSecond times per second
+// and BaseSpeed is the distance in pixels they cover per tick at 100% speed
+const (
+	TicksPerSecond = 60
+	BaseSpeed      = 4.0
+)
+
+// SpeedProfile holds the speed of every movable object as a percentage of BaseSpeed
+type SpeedProfile struct {
+	Pacman            float64 `json:"pacman"`
+	PacmanEating      float64 `json:"pacmanEating"`
+	PowerPacman       float64 `json:"powerPacman"`
+	PowerPacmanEating float64 `json:"powerPacmanEating"`
+	Ghost             float64 `json:"ghost"`
+	GhostTunnel       float64 `json:"ghostTunnel"`
+	ElroyGhost1       float64 `json:"elroyGhost1"`
+	ElroyGhost2       float64 `json:"elroyGhost2"`
+	FleeingGhost      float64 `json:"fleeingGhost"`
+	EatenGhost        float64 `json:"eatenGhost"`
+}
+
 // Default layer indexes for objects
 const (
 	WallLayerIdx         = 6
@@ -35,6 +81,7 @@ const (
 	PacmanLayerIdx       = 3
 	FleeingGhostLayerIdx = 2
 	PelletLayerIdx       = 1
+	ZoneLayerIdx         = 0
 )
 
 // GameState represents the game state
@@ -44,13 +91,47 @@ type GameState int
 // MenuState - Main menu state
 // PlayState - Playing game state
 // GameOverState - A game has finished
+// EditorState - Editing a level
+// LevelSelectState - Choosing the level to play
 const (
 	InactiveState GameState = iota
 	MenuState
 	PlayState
 	GameOverState
+	EditorState
+	LevelSelectState
 )
 
+// GameMode represents a way of playing the game
+type GameMode int
+
+// ClassicMode - A single maze that is won by eating all of its pellets
+// EndlessMode - Clearing a maze loads a new one until PacMan runs out of lives
+// TimeAttackMode - Endless mazes against a countdown that eating ghosts and power pellets extends
+// SurvivalMode - A single maze with respawning pellets and waves of ghosts, scored by survival time
+const (
+	ClassicMode GameMode = iota
+	EndlessMode
+	TimeAttackMode
+	SurvivalMode
+)
+
+// GameModes in the order they are shown in the menu
+var GameModes = []GameMode{
+	ClassicMode,
+	EndlessMode,
+	TimeAttackMode,
+	SurvivalMode,
+}
+
+// GameModeNames used to select a game mode
+var GameModeNames = map[GameMode]string{
+	ClassicMode:    "classic",
+	EndlessMode:    "endless",
+	TimeAttackMode: "timeattack",
+	SurvivalMode:   "survival",
+}
+
 // SoundEffect represents a type of sound effect
 type SoundEffect int
 
@@ -78,22 +159,83 @@ const (
 	MainTheme
 )
 
-// AudioFiles for each sound effect
-var AudioFiles = map[SoundEffect][]string{
-	MunchEffect:      {"assets/audio/munch_1.wav", "assets/audio/munch_2.wav"},
-	GameStart:        {"assets/audio/game_start.wav"},
-	GhostSirenPhase1: {"assets/audio/siren_1.wav"},
-	GhostSirenPhase2: {"assets/audio/siren_2.wav"},
-	GhostSirenPhase3: {"assets/audio/siren_3.wav"},
-	GhostSirenPhase4: {"assets/audio/siren_4.wav"},
-	PowerPellet:      {"assets/audio/power_pellet.wav"},
-	EatGhostEffect:   {"assets/audio/eat_ghost.wav"},
-	Retreating:       {"assets/audio/retreating.wav"},
-	DyingEffect:      {"assets/audio/death.wav"},
-	LevelWon:         {"assets/audio/extend.wav"},
-	MainTheme:        {"assets/audio/intermission.wav"},
+// SoundEffectNames used by the manifests of the sound packs
+var SoundEffectNames = map[SoundEffect]string{
+	MunchEffect:      "munch",
+	GameStart:        "gameStart",
+	GhostSirenPhase1: "sirenPhase1",
+	GhostSirenPhase2: "sirenPhase2",
+	GhostSirenPhase3: "sirenPhase3",
+	GhostSirenPhase4: "sirenPhase4",
+	PowerPellet:      "powerPellet",
+	EatGhostEffect:   "eatGhost",
+	Retreating:       "retreating",
+	DyingEffect:      "dying",
+	LevelWon:         "levelWon",
+	MainTheme:        "mainTheme",
+}
+
+// AudioBus groups sounds that share a volume
+type AudioBus string
+
+// MusicBus - Themes and jingles
+// SirenBus - Background loops of the ghosts
+// SFXBus - Short sound effects
+const (
+	MusicBus AudioBus = "music"
+	SirenBus AudioBus = "siren"
+	SFXBus   AudioBus = "sfx"
+)
+
+// SoundBuses where each sound effect is played
+var SoundBuses = map[SoundEffect]AudioBus{
+	MunchEffect:      SFXBus,
+	GameStart:        MusicBus,
+	GhostSirenPhase1: SirenBus,
+	GhostSirenPhase2: SirenBus,
+	GhostSirenPhase3: SirenBus,
+	GhostSirenPhase4: SirenBus,
+	PowerPellet:      SirenBus,
+	EatGhostEffect:   SFXBus,
+	Retreating:       SirenBus,
+	DyingEffect:      SFXBus,
+	LevelWon:         MusicBus,
+	MainTheme:        MusicBus,
+}
+
+// DuckingEffects lower the volume of the siren bus while they play
+var DuckingEffects = map[SoundEffect]bool{
+	EatGhostEffect: true,
 }
 
+// SoundAction represents what happened to a sound effect
+type SoundAction string
+
+// PlayedOnce - Sound effect played a single time
+// PlayedOnLoop - Sound effect started on loop
+// ReplacedLoop - Sound effect replacing the one on loop
+// StoppedLoop - Sound effect on loop stopped
+const (
+	PlayedOnce   SoundAction = "once"
+	PlayedOnLoop SoundAction = "loop"
+	ReplacedLoop SoundAction = "replace"
+	StoppedLoop  SoundAction = "stop"
+)
+
+// Audio backends
+const (
+	EbitenAudio = "ebiten"
+	NullAudio   = "null"
+)
+
+// Mixer constants
+const (
+	SampleRate        = 44100
+	DuckingVolume     = 0.3
+	CrossfadeDuration = 0.3
+	VolumeStep        = 0.1
+)
+
 // GhostType represents a type of ghost
 type GhostType string
 
@@ -114,6 +256,8 @@ type StateEvent int
 // Scatter - Whenever a ghost starts scattering
 // ChasePacman - Whenever a ghost starts chasing PacMan
 // PowerPelletEaten - Whenever PacMan eats a power pellet
+// LeaveHouse - Whenever a ghost is released from home
+// ExitHouse - Whenever a ghost finally goes out of home
 // PowerPelletWearOff - Whenever PacMan's power pellet wears off
 // StartFlickering - A ghost will get imune to the pellet soon
 // GhostEaten - Whenever pacman eats a ghost
@@ -121,9 +265,12 @@ type StateEvent int
 // PacManEaten - Whenever a ghost eats a pacman
 // GameOver - Whenever the game has finished
 // AllPelletsEaten - Whenever all pellets have been eaten
+// TimeUp - Whenever the countdown of the time attack runs out
 const (
 	Scatter StateEvent = iota
 	ChasePacman
+	LeaveHouse
+	ExitHouse
 	PowerPelletEaten
 	PowerPelletWearOff
 	StartFlickering
@@ -132,22 +279,34 @@ const (
 	PacManEaten
 	GameOver
 	AllPelletsEaten
+	TimeUp
 )
 
+// ReversingEvents force ghosts to turn around whenever they change their state because of them
+var ReversingEvents = map[StateEvent]bool{
+	Scatter:          true,
+	ChasePacman:      true,
+	PowerPelletEaten: true,
+}
+
 // GhostState represents a ghost state
 type GhostState int
 
-// IdleState - Initial ghost value
+// IdleState - Initial ghost value, waiting at home
+// LeavingState - Ghost heading towards the exit of its home
 // ScatterState - Normal ghost behavior
 // ChaseState - Behavior to chase PacMan
+// RoamingState - Either scatter or chase, following the global mode of the level
 // FleeingState - Fleeing PacMan
 // FlickeringState - Still fleeing PacMan but about to stop
 // EatenState - When the Ghost was just eaten by PacMan
 // EndState - Whenever PacMan dies or wins
 const (
 	IdleState GhostState = iota
+	LeavingState
 	ScatterState
 	ChaseState
+	RoamingState
 	FleeingState
 	FlickeringState
 	EatenState
@@ -174,6 +333,11 @@ type Direction struct {
 	Y int
 }
 
+// Reverse of the direction
+func (d Direction) Reverse() Direction {
+	return Direction{X: -d.X, Y: -d.Y}
+}
+
 // IsOpposite to a given direction
 func (d Direction) IsOpposite(other Direction) bool {
 	return d.X*-1 == other.X && d.Y*-1 == other.Y
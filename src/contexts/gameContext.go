@@ -1,6 +1,7 @@
 package contexts
 
 import (
+	"context"
 	"sync"
 
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
@@ -12,20 +13,36 @@ import (
 
 // GameContext represents the game context
 type GameContext struct {
+	Running     context.Context
 	MainPlayer  interfaces.MovableGameObject
 	MazeMutex   sync.Mutex
 	Maze        *structures.Maze
 	GhostHome   interfaces.Location
+	GhostExit   interfaces.Location
 	GhostBases  map[constants.GhostType]interfaces.Location
-	SoundPlayer *modules.SoundPlayer
+	Difficulty  *structures.Difficulty
+	Settings    *structures.LevelSettings
+	Modes       *modules.ModeScheduler
+	Director    *modules.Director
+	SoundPlayer interfaces.SoundPlayer
 	Msg         *structures.MessageBroker
 }
 
 // AnchorContext represents the game context shared among screens
 type AnchorContext struct {
 	ChangeState  chan constants.GameState
+	Assets       *modules.AssetFS
 	AssetManager *modules.AssetManager
-	SoundPlayer  *modules.SoundPlayer
+	SoundPlayer  interfaces.SoundPlayer
 	GameScore    uint
 	FontFace     font.Face
+	LevelFile    string
+	NumEnemies   int
+	Difficulties []*structures.Difficulty
+	Difficulty   *structures.Difficulty
+	Adaptive     bool
+	Mode         constants.GameMode
+	ScoreBoard   *modules.ScoreBoard
+	EditorFile   string
+	PlayTest     bool
 }
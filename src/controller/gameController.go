@@ -4,24 +4,24 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/screens"
-	"github.com/golang/freetype/truetype"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
-	"golang.org/x/image/font"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 )
 
 // GameController represents the main controller of the pacman game
 type GameController struct {
-	nEnemies     int
 	screenWidth  int
 	screenHeight int
 	ctx          *contexts.AnchorContext
+	mixer        *modules.Mixer
 	activeScreen interfaces.Screen
 	isActive     bool
 }
@@ -43,7 +43,11 @@ func (g *GameController) mountScreen(newState constants.GameState) {
 		// Set active screen to the loading screen while the level screen is prepared
 		g.activeScreen = screens.NewLoading(g.screenWidth, g.screenHeight, g.ctx)
 		go func(controller *GameController) {
-			level, err := screens.NewLevel("assets/level1.txt", g.nEnemies, g.ctx)
+			levelFile := g.ctx.LevelFile
+			if g.ctx.PlayTest {
+				levelFile = g.ctx.EditorFile
+			}
+			level, err := screens.NewLevel(levelFile, g.ctx.NumEnemies, g.ctx)
 			if err != nil {
 				log.Fatal(err)
 			}
@@ -52,12 +56,17 @@ func (g *GameController) mountScreen(newState constants.GameState) {
 		}(g)
 	case constants.GameOverState:
 		g.activeScreen = screens.NewGameOver(g.screenWidth, g.screenHeight, g.ctx)
+	case constants.EditorState:
+		g.activeScreen = screens.NewEditor(g.screenWidth, g.screenHeight, g.ctx)
+	case constants.LevelSelectState:
+		g.activeScreen = screens.NewLevelSelect(g.screenWidth, g.screenHeight, g.ctx)
 	}
 	go g.activeScreen.Run()
 }
 
 // Draw the active screen
 func (g *GameController) Draw(mainScreen *ebiten.Image) {
+	mainScreen.Fill(g.ctx.AssetManager.Background)
 	if g.activeScreen != nil {
 		g.activeScreen.Draw(mainScreen)
 	}
@@ -70,13 +79,128 @@ func (g *GameController) InitGame() {
 	g.mountScreen(constants.MenuState)
 }
 
+// busVolumeKeys that lower and raise the volume of each audio bus
+var busVolumeKeys = []struct {
+	bus      constants.AudioBus
+	down, up ebiten.Key
+}{
+	{constants.MusicBus, ebiten.KeyF1, ebiten.KeyF2},
+	{constants.SirenBus, ebiten.KeyF3, ebiten.KeyF4},
+	{constants.SFXBus, ebiten.KeyF5, ebiten.KeyF6},
+}
+
+// Update the controls available in every screen
+func (g *GameController) Update() {
+	var err error
+	mixer := g.mixer
+	if inpututil.IsKeyJustPressed(ebiten.KeyM) {
+		err = mixer.ToggleMute()
+	} else if inpututil.IsKeyJustPressed(ebiten.KeyMinus) {
+		err = mixer.ChangeMasterVolume(-constants.VolumeStep)
+	} else if inpututil.IsKeyJustPressed(ebiten.KeyEqual) {
+		err = mixer.ChangeMasterVolume(constants.VolumeStep)
+	}
+	for _, keys := range busVolumeKeys {
+		if err == nil && inpututil.IsKeyJustPressed(keys.down) {
+			err = mixer.ChangeBusVolume(keys.bus, -constants.VolumeStep)
+		} else if err == nil && inpututil.IsKeyJustPressed(keys.up) {
+			err = mixer.ChangeBusVolume(keys.bus, constants.VolumeStep)
+		}
+	}
+	if err != nil {
+		log.Println(err)
+	}
+}
+
 // IsActive game
 func (g *GameController) IsActive() bool {
 	return g.isActive
 }
 
-// InitGameController instantiaes the main game controller
-func InitGameController(nEnemies int) (*GameController, error) {
+// GenerateLevelFile with a random maze and return the path to it
+func GenerateLevelFile(seed int64) (string, error) {
+	lines, err := modules.InitMazeGenerator(seed).Generate()
+	if err != nil {
+		return "", err
+	}
+
+	file := filepath.Join(os.TempDir(), fmt.Sprintf("pacman-maze-%d.txt", seed))
+	if err := modules.SaveMaze(lines, file); err != nil {
+		return "", err
+	}
+	log.Printf("Generated maze with seed %d at %s", seed, file)
+	return file, nil
+}
+
+// initDataDir where the files of the player are kept, creating it if needed. By default it is
+// a directory of its own in the configuration directory of the user
+func initDataDir(dir string) (string, error) {
+	if dir == "" {
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(configDir, constants.DataDirName)
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	return dir, os.MkdirAll(dir, 0755)
+}
+
+// findGameMode by its name
+func findGameMode(name string) (constants.GameMode, error) {
+	for _, mode := range constants.GameModes {
+		if constants.GameModeNames[mode] == name {
+			return mode, nil
+		}
+	}
+	return constants.ClassicMode, fmt.Errorf("Unknown game mode %q", name)
+}
+
+// initSoundPlayer of the given audio backend. The sound pack is only remembered for the next
+// runs once it was loaded successfully, and a remembered one that cannot be loaded anymore is
+// replaced by the default sound pack instead of preventing the game from starting
+func initSoundPlayer(
+	backend, soundPack string,
+	mixer *modules.Mixer,
+	assets *modules.AssetFS,
+) (interfaces.SoundPlayer, error) {
+	remembered := soundPack == ""
+	if remembered {
+		soundPack = mixer.SoundPack()
+	}
+
+	switch backend {
+	case constants.EbitenAudio:
+		soundPlayer, err := modules.InitEbitenSoundPlayer(mixer, assets, soundPack)
+		if err != nil && remembered && soundPack != constants.DefaultSoundPack {
+			log.Printf("Cannot load the sound pack %s, using %s instead: %v", soundPack, constants.DefaultSoundPack, err)
+			soundPlayer, err = modules.InitEbitenSoundPlayer(mixer, assets, constants.DefaultSoundPack)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if soundPack != mixer.SoundPack() {
+			if err := mixer.SetSoundPack(soundPack); err != nil {
+				return nil, err
+			}
+		}
+		return soundPlayer, nil
+	case constants.NullAudio:
+		return modules.InitNullSoundPlayer(), nil
+	}
+	return nil, fmt.Errorf("Unknown audio backend %q", backend)
+}
+
+// InitGameController instantiaes the main game controller with the assets of the game
+func InitGameController(
+	assets *modules.AssetFS,
+	nEnemies int,
+	levelFile, dataDir, difficultyName, modeName, soundPack, audioBackend, skin string,
+	adaptive, record bool,
+) (*GameController, error) {
 	if nEnemies <= 0 {
 		return nil, errors.New("At least one enemy must be spawned")
 	}
@@ -85,37 +209,70 @@ func InitGameController(nEnemies int) (*GameController, error) {
 		return nil, errors.New(errMsg)
 	}
 
-	assetManager, err := modules.NewAssetManager()
+	assetManager, err := modules.NewAssetManager(assets, skin)
 	if err != nil {
 		return nil, err
 	}
 
-	soundPlayer, err := modules.InitSoundPlayer()
+	dataDir, err = initDataDir(dataDir)
 	if err != nil {
 		return nil, err
 	}
 
-	tt, err := truetype.Parse(fonts.PressStart2P_ttf)
+	mixer, err := modules.LoadMixer(filepath.Join(dataDir, constants.AudioSettingsFile))
 	if err != nil {
 		return nil, err
 	}
 
-	fontFace := truetype.NewFace(tt, &truetype.Options{
-		Size: 30, DPI: 72, Hinting: font.HintingFull,
-	})
+	soundPlayer, err := initSoundPlayer(audioBackend, soundPack, mixer, assets)
+	if err != nil {
+		return nil, err
+	}
+	if record {
+		logger := log.New(os.Stderr, "audio: ", log.LstdFlags)
+		soundPlayer = modules.InitRecordingSoundPlayer(soundPlayer, logger)
+	}
+
+	difficulties, err := modules.LoadDifficulties(assets, constants.DifficultiesFile)
+	if err != nil {
+		return nil, err
+	}
+	difficulty, err := modules.FindDifficulty(difficulties, difficultyName)
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := findGameMode(modeName)
+	if err != nil {
+		return nil, err
+	}
+
+	scoreBoard, err := modules.LoadScoreBoard(filepath.Join(dataDir, constants.HighScoresFile))
+	if err != nil {
+		return nil, err
+	}
 
 	w := constants.HorizontalTiles * constants.TileSize
-	h := constants.VerticalTiles * constants.TileSize + 100
+	h := constants.VerticalTiles*constants.TileSize + 100
 	gameController := GameController{
-		nEnemies:     nEnemies,
 		screenWidth:  w,
 		screenHeight: h,
 		ctx: &contexts.AnchorContext{
 			ChangeState:  make(chan constants.GameState),
+			Assets:       assets,
 			AssetManager: assetManager,
 			SoundPlayer:  soundPlayer,
-			FontFace:     fontFace,
+			LevelFile:    levelFile,
+			NumEnemies:   nEnemies,
+			FontFace:     assetManager.FontFace,
+			Difficulties: difficulties,
+			Difficulty:   difficulty,
+			Adaptive:     adaptive,
+			Mode:         mode,
+			ScoreBoard:   scoreBoard,
+			EditorFile:   filepath.Join(dataDir, constants.EditorLevelFile),
 		},
+		mixer:    mixer,
 		isActive: false,
 	}
 
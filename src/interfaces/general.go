@@ -1,6 +1,7 @@
 package interfaces
 
 import (
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -13,6 +14,11 @@ type Location interface {
 	DistanceTo(Location) float64
 }
 
+// Zone restricts the directions ghosts can take while standing on it
+type Zone interface {
+	AllowsTurn(from, to constants.Direction) bool
+}
+
 // ChaseBehavior of a ghost
 type ChaseBehavior interface {
 	SwitchDirection()
@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+)
+
+// SoundHandle of a sound played once, which can be stopped or waited for
+type SoundHandle interface {
+	Done() <-chan struct{}
+	Stop()
+	Wait(ctx context.Context) error
+}
+
+// LoopingSound represents a sound playing on loop until it is stopped
+type LoopingSound interface {
+	Stop()
+	Replace(effect constants.SoundEffect, crossfade bool)
+}
+
+// SoundPlayer plays the sound effects of the game through any audio backend
+type SoundPlayer interface {
+	PlayOnce(effect constants.SoundEffect)
+	PlayOnceAndNotify(ctx context.Context, effect constants.SoundEffect) SoundHandle
+	PlayOnLoop(ctx context.Context, effect constants.SoundEffect) LoopingSound
+}
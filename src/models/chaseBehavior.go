@@ -23,13 +23,14 @@ type PinkyChaseBehavior struct {
 	ctx   *contexts.GameContext
 }
 
-// SwitchDirection by heading 3 steps in the direction of the player
+// SwitchDirection by heading a few steps in the direction of the player
 func (p *PinkyChaseBehavior) SwitchDirection() {
 	from := p.ctx.MainPlayer.GetPosition()
 	direction := p.ctx.MainPlayer.GetDirection()
+	steps := p.ctx.Difficulty.AI.PinkyLookAhead
 	cols, rows := p.ctx.Maze.Dimensions()
-	toX := utils.Mod(from.X()+direction.X*3, cols)
-	toY := utils.Mod(from.Y()+direction.Y*3, rows)
+	toX := utils.Mod(from.X()+direction.X*steps, cols)
+	toY := utils.Mod(from.Y()+direction.Y*steps, rows)
 	target := structures.InitPosition(toX, toY)
 	p.ghost.turnTowards(target, false, true)
 }
@@ -40,13 +41,14 @@ type InkyChaseBehavior struct {
 	ctx   *contexts.GameContext
 }
 
-// SwitchDirection by heading 3 steps opposite to the direction of the player
+// SwitchDirection by heading a few steps opposite to the direction of the player
 func (i *InkyChaseBehavior) SwitchDirection() {
 	from := i.ctx.MainPlayer.GetPosition()
 	direction := i.ctx.MainPlayer.GetDirection()
+	steps := -i.ctx.Difficulty.AI.InkyLookBehind
 	cols, rows := i.ctx.Maze.Dimensions()
-	toX := utils.Mod(from.X()+direction.X*-3, cols)
-	toY := utils.Mod(from.Y()+direction.Y*-3, rows)
+	toX := utils.Mod(from.X()+direction.X*steps, cols)
+	toY := utils.Mod(from.Y()+direction.Y*steps, rows)
 	target := structures.InitPosition(toX, toY)
 	i.ghost.turnTowards(target, false, true)
 }
@@ -57,11 +59,11 @@ type ClydeChaseBehavior struct {
 	ctx   *contexts.GameContext
 }
 
-// SwitchDirection by heading towards the player only if its distance is greater than 5
+// SwitchDirection by heading towards the player only if it is not too close
 func (i *ClydeChaseBehavior) SwitchDirection() {
 	pacmanPosition := i.ctx.MainPlayer.GetPosition()
 	distance := i.ghost.position.DistanceTo(pacmanPosition)
-	if distance < 3 {
+	if distance < i.ctx.Difficulty.AI.ClydeShyDistance {
 		i.ghost.turnTowards(nil, false, true)
 	} else {
 		i.ghost.turnTowards(pacmanPosition, false, true)
@@ -17,15 +17,17 @@ import (
 // Ghost represents the main enemy
 type Ghost struct {
 	isAlive           bool
+	ctx               *contexts.GameContext
 	state             interfaces.GhostState
 	chaseBehavior     interfaces.ChaseBehavior
 	kind              constants.GhostType
 	layerIndex        int
-	phase             int
 	position          interfaces.Location
-	speed             int
-	idleStateTime     float64
+	speed             float64
+	motion            *structures.Motion
 	direction         constants.Direction
+	reversePending    bool
+	pelletsRemaining  uint
 	sprites           map[string]*structures.SpriteSequence
 	animator          *modules.Animator
 	collisionDetector *modules.CollisionDetector
@@ -37,12 +39,6 @@ func pickRandomDirection() constants.Direction {
 	return allDirections[rand.Intn(len(allDirections))]
 }
 
-func (g *Ghost) advanceSprites() {
-	for _, seq := range g.sprites {
-		seq.Advance()
-	}
-}
-
 func (g *Ghost) orientedSprite() *ebiten.Image {
 	switch g.direction {
 	case constants.DirUp:
@@ -71,8 +67,80 @@ func (g *Ghost) attachChaseBehavior(ctx *contexts.GameContext) {
 	}
 }
 
+func (g *Ghost) tunnelAt() *Tunnel {
+	for _, obj := range g.ctx.Maze.ElementsAt(g.position.X(), g.position.Y()) {
+		if tunnel, ok := obj.(*Tunnel); ok {
+			return tunnel
+		}
+	}
+	return nil
+}
+
+// elroyStage of Blinky given the pellets remaining in the level. Other ghosts are never Cruise Elroy
+func (g *Ghost) elroyStage() int {
+	if g.kind != constants.Blinky {
+		return 0
+	}
+	switch {
+	case g.pelletsRemaining <= g.ctx.Settings.ElroyPellets[1]:
+		return 2
+	case g.pelletsRemaining <= g.ctx.Settings.ElroyPellets[0]:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (g *Ghost) currentSpeed() float64 {
+	speed := g.speed
+	switch g.state.(type) {
+	case *Eaten:
+		return speed
+	case *Scatter, *Chase:
+		switch g.elroyStage() {
+		case 1:
+			speed = g.ctx.Settings.Speeds.ElroyGhost1
+		case 2:
+			speed = g.ctx.Settings.Speeds.ElroyGhost2
+		}
+	}
+	if g.tunnelAt() != nil {
+		speed = math.Min(speed, g.ctx.Settings.Speeds.GhostTunnel)
+	}
+	return g.ctx.Director.GhostSpeed(speed)
+}
+
+// isHome whenever the ghost is waiting to be released
+func (g *Ghost) isHome() bool {
+	_, isIdle := g.state.(*Idle)
+	return isIdle
+}
+
+// isRoaming the maze, whether scattering, chasing or fleeing
+func (g *Ghost) isRoaming() bool {
+	switch g.state.(type) {
+	case *Scatter, *Chase, *Fleeing, *Flickering:
+		return true
+	default:
+		return false
+	}
+}
+
+// respectsZones restrictions only while scattering or chasing, like in the arcade game
+func (g *Ghost) respectsZones() bool {
+	switch g.state.(type) {
+	case *Scatter, *Chase:
+		return true
+	default:
+		return false
+	}
+}
+
 func (g *Ghost) turnTowards(target interfaces.Location, runAway, blockReverse bool) {
-	viableTiles := g.collisionDetector.ViableTiles(blockReverse)
+	if tunnel := g.tunnelAt(); tunnel != nil && !tunnel.allowsTurns {
+		return
+	}
+	viableTiles := g.collisionDetector.ViableTiles(blockReverse, g.respectsZones())
 	options := len(viableTiles)
 	if options == 0 {
 		return
@@ -116,19 +184,40 @@ func (g *Ghost) turnTowards(target interfaces.Location, runAway, blockReverse bo
 }
 
 func (g *Ghost) switchDirection() {
-	if g.chaseBehavior == nil {
+	randomTurnChance := g.ctx.Director.RandomTurnChance(g.ctx.Difficulty.AI.RandomTurnChance)
+	if g.chaseBehavior == nil || rand.Float64() < randomTurnChance {
 		g.turnTowards(nil, false, true)
 	} else {
 		g.chaseBehavior.SwitchDirection()
 	}
 }
 
-// ChangeState given an event
+// reverseIfPending turns the ghost around if a reversal was forced and indicates whether it did
+func (g *Ghost) reverseIfPending() bool {
+	if !g.reversePending {
+		return false
+	}
+	g.reversePending = false
+	g.direction = g.direction.Reverse()
+	return true
+}
+
+// ChangeState given an event. Ghosts out of home turn around on their next step
+// whenever they change state because of a reversing event
 func (g *Ghost) ChangeState(event constants.StateEvent) {
 	newState := g.state.ApplyTransition(event)
-	if newState != nil {
-		g.state = newState
+	if newState == nil || newState == g.state {
+		return
 	}
+
+	wasRoaming := g.isRoaming()
+	g.state = newState
+	g.reversePending = wasRoaming && g.isRoaming() && constants.ReversingEvents[event]
+}
+
+// UpdatePelletsRemaining in the level, which makes Blinky speed up when only a few are left
+func (g *Ghost) UpdatePelletsRemaining(pellets uint) {
+	g.pelletsRemaining = pellets
 }
 
 // AttemptEatPacman given the current ghost state
@@ -142,13 +231,15 @@ func (g *Ghost) Run(ctx *contexts.GameContext) {
 		log.Fatal("Collision detector is not attached")
 	}
 
-	g.state = InitIdle(g, ctx)
-	g.attachChaseBehavior(ctx)
+	ticker := time.NewTicker(time.Second / constants.TicksPerSecond)
+	defer ticker.Stop()
 	for g.isAlive {
+		<-ticker.C
 		ctx.MazeMutex.Lock()
-		g.state.Run()
+		for steps := g.motion.Tick(g.currentSpeed()); steps > 0 && g.isAlive; steps-- {
+			g.state.Run()
+		}
 		ctx.MazeMutex.Unlock()
-		time.Sleep(time.Duration(1000/g.speed) * time.Millisecond)
 	}
 }
 
@@ -201,49 +292,30 @@ func (g *Ghost) AttachCollisionDetector(collisionDetector *modules.CollisionDete
 	g.collisionDetector = collisionDetector
 }
 
-// InitGhost enemy for the level
-func InitGhost(x, y int, idleStateTime float64, ghostType constants.GhostType) (*Ghost, error) {
+// InitGhost enemy for the level with the sprites of its type
+func InitGhost(
+	x, y int,
+	speed float64,
+	direction constants.Direction,
+	ghostType constants.GhostType,
+	sprites map[string]*structures.SpriteSequence,
+	ctx *contexts.GameContext,
+) (*Ghost, error) {
 	ghost := Ghost{
-		isAlive:       true,
-		layerIndex:    constants.GhostLayerIdx,
-		kind:          ghostType,
-		phase:         0,
-		position:      structures.InitPosition(x, y),
-		idleStateTime: idleStateTime,
-		speed:         constants.DefaultGhostFPS,
-		sprites:       make(map[string]*structures.SpriteSequence),
-	}
-
-	categories := []string{"left", "right", "down", "up", "panic", "flicker"}
-	for _, category := range categories {
-		gType := string(ghostType)
-		if category == "panic" || category == "flicker" {
-			gType = ""
-		}
-		sprites := []string{
-			"assets/ghost/" + gType + "/ghost-" + category + "-1.png",
-			"assets/ghost/" + gType + "/ghost-" + category + "-2.png",
-		}
-		seq, err := structures.InitSpriteSequence(sprites)
-		if err != nil {
-			return nil, err
-		}
-		ghost.sprites[category] = seq
-	}
-
-	categories = []string{"eaten-left", "eaten-right", "eaten-down", "eaten-up"}
-	for _, category := range categories {
-		sprites := []string{
-			"assets/ghost/ghost-" + category + ".png",
-		}
-		seq, err := structures.InitSpriteSequence(sprites)
-		if err != nil {
-			return nil, err
-		}
-		ghost.sprites[category] = seq
+		isAlive:    true,
+		layerIndex: constants.GhostLayerIdx,
+		kind:       ghostType,
+		position:   structures.InitPosition(x, y),
+		speed:      speed,
+		motion:     structures.InitMotion(),
+		direction:  direction,
+		sprites:    sprites,
 	}
 
-	ghost.direction = pickRandomDirection()
 	ghost.animator = modules.InitAnimator(&ghost)
+	// Ghosts start idle at home, so that events sent before they run are never lost
+	ghost.ctx = ctx
+	ghost.state = InitIdle(&ghost, ctx)
+	ghost.attachChaseBehavior(ctx)
 	return &ghost, nil
 }
@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
+)
+
+// GhostHouse decides when the ghosts waiting at home are released, following the
+// pellet counters of the original arcade game
+type GhostHouse struct {
+	ctx            *contexts.GameContext
+	ghosts         []*Ghost
+	next           int
+	pelletCounters []int
+	globalCounter  int
+	useGlobal      bool
+	lastPelletAt   time.Time
+	lastReleaseAt  time.Time
+}
+
+func (h *GhostHouse) pelletLimit(idx int) int {
+	if h.useGlobal {
+		limits := h.ctx.Difficulty.GlobalPelletLimits
+		if idx >= len(limits) {
+			idx = len(limits) - 1
+		}
+		return limits[idx]
+	}
+	return h.ctx.Settings.GhostPelletLimits[h.ghosts[idx].kind]
+}
+
+func (h *GhostHouse) pelletCount(idx int) int {
+	if h.useGlobal {
+		return h.globalCounter
+	}
+	return h.pelletCounters[idx]
+}
+
+func (h *GhostHouse) release() {
+	h.ghosts[h.next].ChangeState(constants.LeaveHouse)
+	h.next++
+	h.lastReleaseAt = time.Now()
+	if h.next == len(h.ghosts) {
+		h.useGlobal = false
+	}
+}
+
+// PelletEaten by PacMan, which increases the counter of the ghost to be released next
+func (h *GhostHouse) PelletEaten() {
+	h.lastPelletAt = time.Now()
+	if h.next == len(h.ghosts) {
+		return
+	}
+	if h.useGlobal {
+		h.globalCounter++
+	} else {
+		h.pelletCounters[h.next]++
+	}
+	h.Update()
+}
+
+// Admit a ghost spawned in the middle of a round, to be released after the ones already waiting
+func (h *GhostHouse) Admit(ghost *Ghost) {
+	h.ghosts = append(h.ghosts, ghost)
+	h.pelletCounters = append(h.pelletCounters, 0)
+}
+
+// LifeLost so that ghosts are released by a single global counter
+func (h *GhostHouse) LifeLost() {
+	h.useGlobal = true
+	h.globalCounter = 0
+}
+
+// Update the house and release the next ghost if its counter reached the limit
+// or if PacMan has not eaten a pellet for a while
+func (h *GhostHouse) Update() {
+	if h.next == len(h.ghosts) || !h.ghosts[h.next].isHome() {
+		return
+	}
+	if time.Now().Sub(h.lastReleaseAt).Seconds() < constants.MinTimeBetweenReleases {
+		return
+	}
+
+	if h.pelletCount(h.next) >= h.pelletLimit(h.next) {
+		h.release()
+	} else if time.Now().Sub(h.lastPelletAt).Seconds() > h.ctx.Settings.ReleaseTimeout {
+		h.lastPelletAt = time.Now()
+		h.release()
+	}
+}
+
+// InitGhostHouse with the ghosts waiting at home in release order
+func InitGhostHouse(ghosts []*Ghost, ctx *contexts.GameContext) *GhostHouse {
+	return &GhostHouse{
+		ctx:            ctx,
+		ghosts:         ghosts,
+		next:           0,
+		pelletCounters: make([]int, len(ghosts)),
+		lastPelletAt:   time.Now(),
+	}
+}
@@ -1,12 +1,12 @@
 package models
 
 import (
+	"math"
 	"time"
 
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
-	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
@@ -18,10 +18,17 @@ func getGhostStateInstance(
 	switch state {
 	case constants.IdleState:
 		return InitIdle(ghost, ctx)
+	case constants.LeavingState:
+		return InitLeaving(ghost, ctx)
 	case constants.ScatterState:
 		return InitScatter(ghost, ctx)
 	case constants.ChaseState:
 		return InitChase(ghost, ctx)
+	case constants.RoamingState:
+		if ctx.Modes.Mode() == constants.ChasePacman {
+			return InitChase(ghost, ctx)
+		}
+		return InitScatter(ghost, ctx)
 	case constants.FleeingState:
 		return InitFleeing(ghost, ctx)
 	case constants.FlickeringState:
@@ -44,7 +51,6 @@ type Idle struct {
 	ghost       *Ghost
 	ctx         *contexts.GameContext
 	transitions map[constants.StateEvent]constants.GhostState
-	createdAt   time.Time
 }
 
 // ApplyTransition given an event
@@ -62,12 +68,18 @@ func (i *Idle) AttemptEatPacman(obj interfaces.MovableGameObject) bool {
 	return false
 }
 
-// Run main logic of state
+// Run main logic of state by bobbing inside home until released
 func (i *Idle) Run() {
-	i.ghost.advanceSprites()
-	if time.Now().Sub(i.createdAt).Seconds() > i.ghost.idleStateTime {
-		i.ghost.ChangeState(constants.Scatter)
+	targets := i.ghost.collisionDetector.DetectCollision()
+	for _, target := range targets {
+		switch target.(type) {
+		case *Wall, *Bars:
+			i.ghost.direction = i.ghost.direction.Reverse()
+			return
+		}
 	}
+
+	i.ctx.Maze.MoveElement(i.ghost)
 }
 
 // GetSprite corresponding to state
@@ -77,17 +89,91 @@ func (i *Idle) GetSprite() *ebiten.Image {
 
 // InitIdle state instance
 func InitIdle(ghost *Ghost, ctx *contexts.GameContext) *Idle {
+	// Ghosts bob around home as slow as they go through tunnels
+	ghost.speed = ctx.Settings.Speeds.GhostTunnel
 	idle := Idle{
 		ghost:       ghost,
 		ctx:         ctx,
 		transitions: make(map[constants.StateEvent]constants.GhostState),
-		createdAt:   time.Now(),
 	}
-	idle.transitions[constants.Scatter] = constants.ScatterState
+	idle.transitions[constants.LeaveHouse] = constants.LeavingState
 	idle.transitions[constants.GameOver] = constants.EndState
 	return &idle
 }
 
+//----------------------------------------------------------------------------//
+//---------------------------------LEAVING------------------------------------//
+//----------------------------------------------------------------------------//
+
+// Leaving state of a ghost
+type Leaving struct {
+	ghost       *Ghost
+	ctx         *contexts.GameContext
+	transitions map[constants.StateEvent]constants.GhostState
+}
+
+// ApplyTransition given an event
+func (l *Leaving) ApplyTransition(event constants.StateEvent) interfaces.GhostState {
+	state, found := l.transitions[event]
+	if !found {
+		return l
+	}
+
+	return getGhostStateInstance(state, l.ghost, l.ctx)
+}
+
+// AttemptEatPacman given the current state
+func (l *Leaving) AttemptEatPacman(obj interfaces.MovableGameObject) bool {
+	pacman, ok := obj.(*Pacman)
+	if !ok {
+		return false
+	}
+
+	pacman.ChangeState(constants.PacManEaten)
+	return true
+}
+
+// Run main logic of state by heading through the bars towards the exit of home
+func (l *Leaving) Run() {
+	l.ghost.turnTowards(l.ctx.GhostExit, false, false)
+	shouldMove := true
+	targets := l.ghost.collisionDetector.DetectCollision()
+	for _, target := range targets {
+		switch obj := target.(type) {
+		case *Wall:
+			shouldMove = false
+		case *Pacman:
+			l.AttemptEatPacman(obj)
+		}
+	}
+
+	if shouldMove {
+		l.ctx.Maze.MoveElement(l.ghost)
+	}
+	if l.ghost.position.DistanceTo(l.ctx.GhostExit) < 1 {
+		l.ghost.ChangeState(constants.ExitHouse)
+	}
+}
+
+// GetSprite corresponding to state
+func (l *Leaving) GetSprite() *ebiten.Image {
+	return l.ghost.orientedSprite()
+}
+
+// InitLeaving state instance
+func InitLeaving(ghost *Ghost, ctx *contexts.GameContext) *Leaving {
+	ghost.layerIndex = constants.GhostLayerIdx
+	ghost.speed = ctx.Settings.Speeds.GhostTunnel
+	leaving := Leaving{
+		ghost:       ghost,
+		ctx:         ctx,
+		transitions: make(map[constants.StateEvent]constants.GhostState),
+	}
+	leaving.transitions[constants.ExitHouse] = constants.RoamingState
+	leaving.transitions[constants.GameOver] = constants.EndState
+	return &leaving
+}
+
 //----------------------------------------------------------------------------//
 //---------------------------------SCATTER------------------------------------//
 //----------------------------------------------------------------------------//
@@ -97,7 +183,6 @@ type Scatter struct {
 	ghost                    *Ghost
 	ctx                      *contexts.GameContext
 	transitions              map[constants.StateEvent]constants.GhostState
-	createdAt                time.Time
 	prevDirection            constants.Direction
 	recentlyChangedDirection bool
 }
@@ -125,8 +210,13 @@ func (s *Scatter) AttemptEatPacman(obj interfaces.MovableGameObject) bool {
 
 // Run main logic of state
 func (s *Scatter) Run() {
-	if !s.recentlyChangedDirection {
-		s.ghost.turnTowards(s.ctx.GhostBases[s.ghost.kind], false, true)
+	if !s.ghost.reverseIfPending() && !s.recentlyChangedDirection {
+		// Blinky keeps chasing PacMan during scatter in its second Cruise Elroy stage
+		if s.ghost.elroyStage() == 2 {
+			s.ghost.switchDirection()
+		} else {
+			s.ghost.turnTowards(s.ctx.GhostBases[s.ghost.kind], false, true)
+		}
 	}
 	s.recentlyChangedDirection = s.ghost.direction != s.prevDirection
 	if !s.recentlyChangedDirection {
@@ -144,13 +234,9 @@ func (s *Scatter) Run() {
 
 		if shouldMove {
 			s.ctx.Maze.MoveElement(s.ghost)
-			s.ghost.advanceSprites()
 		}
 	}
 	s.prevDirection = s.ghost.direction
-	if time.Now().Sub(s.createdAt).Seconds() > constants.ScatterModeDuration {
-		s.ghost.ChangeState(constants.ChasePacman)
-	}
 }
 
 // GetSprite corresponding to state
@@ -161,12 +247,11 @@ func (s *Scatter) GetSprite() *ebiten.Image {
 // InitScatter state instance
 func InitScatter(ghost *Ghost, ctx *contexts.GameContext) *Scatter {
 	ghost.layerIndex = constants.GhostLayerIdx
-	ghost.speed = constants.DefaultGhostFPS
+	ghost.speed = ctx.Settings.Speeds.Ghost
 	scatter := Scatter{
 		ghost:                    ghost,
 		ctx:                      ctx,
 		transitions:              make(map[constants.StateEvent]constants.GhostState),
-		createdAt:                time.Now(),
 		prevDirection:            ghost.direction,
 		recentlyChangedDirection: false,
 	}
@@ -185,7 +270,6 @@ type Chase struct {
 	ghost                    *Ghost
 	ctx                      *contexts.GameContext
 	transitions              map[constants.StateEvent]constants.GhostState
-	createdAt                time.Time
 	prevDirection            constants.Direction
 	recentlyChangedDirection bool
 }
@@ -213,7 +297,7 @@ func (c *Chase) AttemptEatPacman(obj interfaces.MovableGameObject) bool {
 
 // Run main logic of state
 func (c *Chase) Run() {
-	if !c.recentlyChangedDirection {
+	if !c.ghost.reverseIfPending() && !c.recentlyChangedDirection {
 		c.ghost.switchDirection()
 	}
 	c.recentlyChangedDirection = c.ghost.direction != c.prevDirection
@@ -232,16 +316,9 @@ func (c *Chase) Run() {
 
 		if shouldMove {
 			c.ctx.Maze.MoveElement(c.ghost)
-			c.ghost.advanceSprites()
 		}
 	}
 	c.prevDirection = c.ghost.direction
-	timer := time.Now().Sub(c.createdAt).Seconds()
-	if c.ghost.phase < constants.InfiniteChasePhase && timer > constants.ChaseModeDuration {
-		c.ghost.phase++
-		c.ctx.Msg.PhaseChange <- c.ghost.phase
-		c.ghost.ChangeState(constants.Scatter)
-	}
 }
 
 // GetSprite corresponding to state
@@ -252,12 +329,11 @@ func (c *Chase) GetSprite() *ebiten.Image {
 // InitChase state instance
 func InitChase(ghost *Ghost, ctx *contexts.GameContext) *Chase {
 	ghost.layerIndex = constants.GhostLayerIdx
-	ghost.speed = constants.DefaultGhostFPS
+	ghost.speed = ctx.Settings.Speeds.Ghost
 	chase := Chase{
 		ghost:                    ghost,
 		ctx:                      ctx,
 		transitions:              make(map[constants.StateEvent]constants.GhostState),
-		createdAt:                time.Now(),
 		prevDirection:            ghost.direction,
 		recentlyChangedDirection: false,
 	}
@@ -278,7 +354,6 @@ type Fleeing struct {
 	transitions              map[constants.StateEvent]constants.GhostState
 	createdAt                time.Time
 	prevDirection            constants.Direction
-	blockReverse             bool
 	recentlyChangedDirection bool
 }
 
@@ -304,9 +379,8 @@ func (f *Fleeing) AttemptEatPacman(obj interfaces.MovableGameObject) bool {
 
 // Run main logic of state
 func (f *Fleeing) Run() {
-	if !f.recentlyChangedDirection {
-		f.ghost.turnTowards(f.ctx.MainPlayer.GetPosition(), true, f.blockReverse)
-		f.blockReverse = true
+	if !f.ghost.reverseIfPending() && !f.recentlyChangedDirection {
+		f.ghost.turnTowards(f.ctx.MainPlayer.GetPosition(), true, true)
 	}
 	f.recentlyChangedDirection = f.ghost.direction != f.prevDirection
 	if !f.recentlyChangedDirection {
@@ -324,12 +398,12 @@ func (f *Fleeing) Run() {
 
 		if shouldMove {
 			f.ctx.Maze.MoveElement(f.ghost)
-			f.ghost.advanceSprites()
 		}
 	}
 	f.prevDirection = f.ghost.direction
 	timer := time.Now().Sub(f.createdAt).Seconds()
-	if timer > constants.PowerPelletDuration-constants.FlickeringStateDuration {
+	duration := f.ctx.Director.FrightenedTime(f.ctx.Settings.PowerPelletDuration)
+	if timer > math.Max(0, duration-f.ctx.Settings.FlickeringDuration) {
 		f.ghost.ChangeState(constants.StartFlickering)
 	}
 }
@@ -342,14 +416,13 @@ func (f *Fleeing) GetSprite() *ebiten.Image {
 // InitFleeing state instance
 func InitFleeing(ghost *Ghost, ctx *contexts.GameContext) *Fleeing {
 	ghost.layerIndex = constants.FleeingGhostLayerIdx
-	ghost.speed = constants.FleeingGhostFPS
+	ghost.speed = ctx.Settings.Speeds.FleeingGhost
 	fleeing := Fleeing{
 		ghost:                    ghost,
 		ctx:                      ctx,
 		transitions:              make(map[constants.StateEvent]constants.GhostState),
 		createdAt:                time.Now(),
 		prevDirection:            ghost.direction,
-		blockReverse:             false,
 		recentlyChangedDirection: false,
 	}
 	fleeing.transitions[constants.StartFlickering] = constants.FlickeringState
@@ -395,7 +468,7 @@ func (f *Flickering) AttemptEatPacman(obj interfaces.MovableGameObject) bool {
 
 // Run main logic of state
 func (f *Flickering) Run() {
-	if !f.recentlyChangedDirection {
+	if !f.ghost.reverseIfPending() && !f.recentlyChangedDirection {
 		f.ghost.turnTowards(f.ctx.MainPlayer.GetPosition(), true, true)
 	}
 	f.recentlyChangedDirection = f.ghost.direction != f.prevDirection
@@ -414,12 +487,12 @@ func (f *Flickering) Run() {
 
 		if shouldMove {
 			f.ctx.Maze.MoveElement(f.ghost)
-			f.ghost.advanceSprites()
 		}
 	}
 	f.prevDirection = f.ghost.direction
 	timer := time.Now().Sub(f.createdAt).Seconds()
-	if timer > constants.FlickeringStateDuration {
+	duration := f.ctx.Director.FrightenedTime(f.ctx.Settings.PowerPelletDuration)
+	if timer > math.Min(f.ctx.Settings.FlickeringDuration, duration) {
 		f.ghost.ChangeState(constants.PowerPelletWearOff)
 	}
 }
@@ -432,7 +505,7 @@ func (f *Flickering) GetSprite() *ebiten.Image {
 // InitFlickering state instance
 func InitFlickering(ghost *Ghost, ctx *contexts.GameContext) *Flickering {
 	ghost.layerIndex = constants.FleeingGhostLayerIdx
-	ghost.speed = constants.FleeingGhostFPS
+	ghost.speed = ctx.Settings.Speeds.FleeingGhost
 	flickering := Flickering{
 		ghost:                    ghost,
 		ctx:                      ctx,
@@ -441,7 +514,7 @@ func InitFlickering(ghost *Ghost, ctx *contexts.GameContext) *Flickering {
 		prevDirection:            ghost.direction,
 		recentlyChangedDirection: false,
 	}
-	flickering.transitions[constants.PowerPelletWearOff] = constants.ChaseState
+	flickering.transitions[constants.PowerPelletWearOff] = constants.RoamingState
 	flickering.transitions[constants.GhostEaten] = constants.EatenState
 	flickering.transitions[constants.PowerPelletEaten] = constants.FleeingState
 	flickering.transitions[constants.GameOver] = constants.EndState
@@ -458,7 +531,7 @@ type Eaten struct {
 	ctx           *contexts.GameContext
 	transitions   map[constants.StateEvent]constants.GhostState
 	prevDirection constants.Direction
-	audioEffect   *modules.InfiniteAudio
+	audioEffect   interfaces.LoopingSound
 }
 
 // ApplyTransition given an event
@@ -492,7 +565,6 @@ func (e *Eaten) Run() {
 
 	if shouldMove {
 		e.ctx.Maze.MoveElement(e.ghost)
-		e.ghost.advanceSprites()
 	}
 	e.prevDirection = e.ghost.direction
 	if e.ghost.position.DistanceTo(e.ctx.GhostHome) < 1 {
@@ -519,15 +591,15 @@ func (e *Eaten) GetSprite() *ebiten.Image {
 // InitEaten state instance
 func InitEaten(ghost *Ghost, ctx *contexts.GameContext) *Eaten {
 	ghost.layerIndex = constants.FleeingGhostLayerIdx
-	ghost.speed = constants.EatenGhostFPS
+	ghost.speed = ctx.Settings.Speeds.EatenGhost
 	eaten := Eaten{
 		ghost:         ghost,
 		ctx:           ctx,
 		transitions:   make(map[constants.StateEvent]constants.GhostState),
 		prevDirection: ghost.direction,
-		audioEffect:   ctx.SoundPlayer.PlayOnLoop(constants.Retreating),
+		audioEffect:   ctx.SoundPlayer.PlayOnLoop(ctx.Running, constants.Retreating),
 	}
-	eaten.transitions[constants.ReachBase] = constants.ScatterState
+	eaten.transitions[constants.ReachBase] = constants.LeavingState
 	eaten.transitions[constants.GameOver] = constants.EndState
 	return &eaten
 }
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
+)
+
+// initTestGhost going left in the given state, without a maze to move through
+func initTestGhost(initState func(*Ghost, *contexts.GameContext) interfaces.GhostState) *Ghost {
+	ctx := &contexts.GameContext{Modes: modules.InitModeScheduler([]float64{7, 20})}
+	ghost := &Ghost{kind: constants.Blinky, direction: constants.DirLeft}
+	ghost.state = initState(ghost, ctx)
+	return ghost
+}
+
+func TestChangeStateReversesOnce(t *testing.T) {
+	idle := func(g *Ghost, ctx *contexts.GameContext) interfaces.GhostState { return InitIdle(g, ctx) }
+	leaving := func(g *Ghost, ctx *contexts.GameContext) interfaces.GhostState { return InitLeaving(g, ctx) }
+	scatter := func(g *Ghost, ctx *contexts.GameContext) interfaces.GhostState { return InitScatter(g, ctx) }
+	chase := func(g *Ghost, ctx *contexts.GameContext) interfaces.GhostState { return InitChase(g, ctx) }
+	fleeing := func(g *Ghost, ctx *contexts.GameContext) interfaces.GhostState { return InitFleeing(g, ctx) }
+	// Eaten ghosts are created without the retreating sound, which needs an audio device
+	eaten := func(g *Ghost, ctx *contexts.GameContext) interfaces.GhostState { return &Eaten{ghost: g, ctx: ctx} }
+
+	tests := []struct {
+		name     string
+		state    func(*Ghost, *contexts.GameContext) interfaces.GhostState
+		event    constants.StateEvent
+		reverses bool
+	}{
+		{"scatter to chase", scatter, constants.ChasePacman, true},
+		{"chase to scatter", chase, constants.Scatter, true},
+		{"scatter to fleeing", scatter, constants.PowerPelletEaten, true},
+		{"chase to fleeing", chase, constants.PowerPelletEaten, true},
+		{"fleeing again", fleeing, constants.PowerPelletEaten, true},
+		{"fleeing to flickering", fleeing, constants.StartFlickering, false},
+		{"idle on scatter", idle, constants.Scatter, false},
+		{"idle on chase", idle, constants.ChasePacman, false},
+		{"idle on power pellet", idle, constants.PowerPelletEaten, false},
+		{"leaving on scatter", leaving, constants.Scatter, false},
+		{"leaving on chase", leaving, constants.ChasePacman, false},
+		{"leaving on power pellet", leaving, constants.PowerPelletEaten, false},
+		{"eaten on scatter", eaten, constants.Scatter, false},
+		{"eaten on chase", eaten, constants.ChasePacman, false},
+		{"eaten on power pellet", eaten, constants.PowerPelletEaten, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ghost := initTestGhost(test.state)
+			ghost.ChangeState(test.event)
+
+			turns := 0
+			for step := 0; step < 3; step++ {
+				if ghost.reverseIfPending() {
+					turns++
+				}
+			}
+
+			want, direction := 0, constants.DirLeft
+			if test.reverses {
+				want, direction = 1, constants.DirRight
+			}
+			if turns != want {
+				t.Errorf("got %d turns, want %d", turns, want)
+			}
+			if ghost.direction != direction {
+				t.Errorf("got direction %v, want %v", ghost.direction, direction)
+			}
+		})
+	}
+}
@@ -20,7 +20,8 @@ type Pacman struct {
 	keepRunning       bool
 	state             interfaces.PacmanState
 	position          interfaces.Location
-	speed             int
+	speed             float64
+	motion            *structures.Motion
 	keyDirection      constants.Direction
 	direction         constants.Direction
 	sprites           map[string]*structures.SpriteSequence
@@ -30,7 +31,7 @@ type Pacman struct {
 
 func (p *Pacman) keyListener() {
 	lastPressed := time.Now()
-	for {
+	for p.keepRunning {
 		if ebiten.IsKeyPressed(ebiten.KeyUp) {
 			p.keyDirection = constants.DirUp
 			lastPressed = time.Now()
@@ -81,10 +82,19 @@ func (p *Pacman) EatGhost(g *Ghost, ctx *contexts.GameContext) {
 	p.scoreMutex.Lock()
 	p.Score += 200
 	p.scoreMutex.Unlock()
+	ctx.Director.GhostEaten()
+	ctx.Msg.EatGhost <- struct{}{}
 	ctx.SoundPlayer.PlayOnce(constants.EatGhostEffect)
 	g.ChangeState(constants.GhostEaten)
 }
 
+// AddScore not related to eating, such as the points for surviving
+func (p *Pacman) AddScore(points uint) {
+	p.scoreMutex.Lock()
+	p.Score += points
+	p.scoreMutex.Unlock()
+}
+
 // Run the behavior of the player
 func (p *Pacman) Run(ctx *contexts.GameContext) {
 	if p.collisionDetector == nil {
@@ -93,11 +103,15 @@ func (p *Pacman) Run(ctx *contexts.GameContext) {
 
 	p.state = InitWalking(p, ctx)
 	go p.keyListener()
+	ticker := time.NewTicker(time.Second / constants.TicksPerSecond)
+	defer ticker.Stop()
 	for p.keepRunning {
-		ctx.MazeMutex.Lock()
-		p.state.Run()
-		ctx.MazeMutex.Unlock()
-		time.Sleep(time.Duration(1000/p.speed) * time.Millisecond)
+		<-ticker.C
+		for steps := p.motion.Tick(p.speed); steps > 0 && p.keepRunning; steps-- {
+			ctx.MazeMutex.Lock()
+			p.state.Run()
+			ctx.MazeMutex.Unlock()
+		}
 	}
 }
 
@@ -151,12 +165,13 @@ func (p *Pacman) AttachCollisionDetector(collisionDetector *modules.CollisionDet
 }
 
 // InitPacman player for the level
-func InitPacman(x, y int, assetManager *modules.AssetManager) *Pacman {
+func InitPacman(x, y int, speed float64, assetManager *modules.AssetManager) *Pacman {
 	pacman := Pacman{
 		Score:        0,
 		keepRunning:  true,
 		position:     structures.InitPosition(x, y),
-		speed:        constants.DefaultPacmanFPS,
+		speed:        speed,
+		motion:       structures.InitMotion(),
 		direction:    constants.DirLeft,
 		keyDirection: constants.DirLeft,
 		sprites:      make(map[string]*structures.SpriteSequence),
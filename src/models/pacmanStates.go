@@ -59,9 +59,13 @@ targetsLoop:
 			if w.pacman.direction != w.prevDirection {
 				w.pacman.direction = w.prevDirection
 				w.handleCollisions()
+			} else {
+				// PacMan stops chomping while it cannot move
+				w.pacman.sprites["alive"].Pause()
 			}
 			return
 		case *Pellet:
+			w.pacman.speed = w.ctx.Settings.Speeds.PacmanEating
 			w.pacman.EatPellet(obj, w.ctx)
 		case *Ghost:
 			if obj.AttemptEatPacman(w.pacman) {
@@ -70,7 +74,7 @@ targetsLoop:
 			}
 		}
 	}
-	w.pacman.sprites["alive"].Advance()
+	w.pacman.sprites["alive"].Resume()
 	w.ctx.Maze.MoveElement(w.pacman)
 }
 
@@ -79,6 +83,7 @@ func (w *Walking) Run() {
 	if w.pacman.keyDirection != constants.DirStatic {
 		w.pacman.direction = w.pacman.keyDirection
 	}
+	w.pacman.speed = w.ctx.Settings.Speeds.Pacman
 	w.handleCollisions()
 	w.prevDirection = w.pacman.direction
 }
@@ -90,7 +95,7 @@ func (w *Walking) GetSprite() *ebiten.Image {
 
 // InitWalking state instance
 func InitWalking(pacman *Pacman, ctx *contexts.GameContext) *Walking {
-	pacman.speed = constants.DefaultPacmanFPS
+	pacman.speed = ctx.Settings.Speeds.Pacman
 	walking := Walking{
 		pacman:        pacman,
 		ctx:           ctx,
@@ -100,6 +105,7 @@ func InitWalking(pacman *Pacman, ctx *contexts.GameContext) *Walking {
 	walking.transitions[constants.PowerPelletEaten] = constants.PowerState
 	walking.transitions[constants.PacManEaten] = constants.DeadState
 	walking.transitions[constants.AllPelletsEaten] = constants.WinState
+	walking.transitions[constants.TimeUp] = constants.WinState
 	return &walking
 }
 
@@ -135,9 +141,13 @@ targetsLoop:
 			if p.pacman.direction != p.prevDirection {
 				p.pacman.direction = p.prevDirection
 				p.handleCollisions()
+			} else {
+				// PacMan stops chomping while it cannot move
+				p.pacman.sprites["alive"].Pause()
 			}
 			return
 		case *Pellet:
+			p.pacman.speed = p.ctx.Settings.Speeds.PowerPacmanEating
 			p.pacman.EatPellet(obj, p.ctx)
 		case *Ghost:
 			if obj.AttemptEatPacman(p.pacman) {
@@ -146,7 +156,7 @@ targetsLoop:
 			}
 		}
 	}
-	p.pacman.sprites["alive"].Advance()
+	p.pacman.sprites["alive"].Resume()
 	p.ctx.Maze.MoveElement(p.pacman)
 }
 
@@ -155,10 +165,11 @@ func (p *Power) Run() {
 	if p.pacman.keyDirection != constants.DirStatic {
 		p.pacman.direction = p.pacman.keyDirection
 	}
+	p.pacman.speed = p.ctx.Settings.Speeds.PowerPacman
 	p.handleCollisions()
 	p.prevDirection = p.pacman.direction
 	timer := time.Now().Sub(p.createdAt).Seconds()
-	if timer > constants.PowerPelletDuration {
+	if timer > p.ctx.Director.FrightenedTime(p.ctx.Settings.PowerPelletDuration) {
 		p.ctx.Msg.PowerPelletWoreOff <- struct{}{}
 		p.pacman.ChangeState(constants.PowerPelletWearOff)
 	}
@@ -171,7 +182,7 @@ func (p *Power) GetSprite() *ebiten.Image {
 
 // InitPower state instance
 func InitPower(pacman *Pacman, ctx *contexts.GameContext) *Power {
-	pacman.speed = constants.PowerPacmanFPS
+	pacman.speed = ctx.Settings.Speeds.PowerPacman
 	power := Power{
 		pacman:        pacman,
 		ctx:           ctx,
@@ -183,6 +194,7 @@ func InitPower(pacman *Pacman, ctx *contexts.GameContext) *Power {
 	power.transitions[constants.PowerPelletWearOff] = constants.WalkingState
 	power.transitions[constants.PacManEaten] = constants.DeadState
 	power.transitions[constants.AllPelletsEaten] = constants.WinState
+	power.transitions[constants.TimeUp] = constants.WinState
 	return &power
 }
 
@@ -205,10 +217,10 @@ func (w *Dead) ApplyTransition(event constants.StateEvent) interfaces.PacmanStat
 // Run main logic of state
 func (w *Dead) Run() {
 	if !w.finishedAnimation {
-		w.finishedAnimation = w.pacman.sprites["dead"].Advance()
+		w.finishedAnimation = w.pacman.sprites["dead"].Finished()
 	} else {
 		w.ctx.Maze.RemoveElement(w.pacman)
-		w.ctx.Msg.EndGame <- struct{}{}
+		w.ctx.Msg.PacmanDied <- struct{}{}
 		w.pacman.keepRunning = false
 	}
 }
@@ -225,6 +237,7 @@ func (w *Dead) GetSprite() *ebiten.Image {
 func InitDead(pacman *Pacman, ctx *contexts.GameContext) *Dead {
 	ctx.SoundPlayer.PlayOnce(constants.DyingEffect)
 	ctx.Msg.RemoveEnemies <- struct{}{}
+	pacman.sprites["dead"].Reset()
 	return &Dead{
 		finishedAnimation: false,
 		pacman:            pacman,
@@ -259,9 +272,7 @@ func InitWin(pacman *Pacman, ctx *contexts.GameContext) *Win {
 	pacman.keepRunning = false
 	go func(pacman *Pacman, ctx *contexts.GameContext) {
 		ctx.Msg.RemoveEnemies <- struct{}{}
-		wait := make(chan struct{})
-		ctx.SoundPlayer.PlayOnceAndNotify(constants.LevelWon, wait)
-		<-wait
+		<-ctx.SoundPlayer.PlayOnceAndNotify(ctx.Running, constants.LevelWon).Done()
 		ctx.Maze.RemoveElement(pacman)
 		ctx.Msg.EndGame <- struct{}{}
 	}(pacman, ctx)
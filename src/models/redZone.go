@@ -0,0 +1,58 @@
+package models
+
+import (
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// RedZone represents an invisible intersection where ghosts cannot turn upwards
+type RedZone struct {
+	position interfaces.Location
+}
+
+// AllowsTurn to a new direction unless it means going upwards
+func (r *RedZone) AllowsTurn(from, to constants.Direction) bool {
+	return to != constants.DirUp || from == constants.DirUp
+}
+
+// Draw nothing, red zones look like empty space
+func (r *RedZone) Draw(screen *ebiten.Image, x, y int) {}
+
+// GetSprite of the element
+func (r *RedZone) GetSprite() *ebiten.Image {
+	return nil
+}
+
+// GetDirection of the element
+func (r *RedZone) GetDirection() constants.Direction {
+	return constants.DirStatic
+}
+
+// IsMatrixEditable based on the object direction
+func (r *RedZone) IsMatrixEditable() bool {
+	return false
+}
+
+// CanGhostsGoThrough by any force
+func (r *RedZone) CanGhostsGoThrough() bool {
+	return true
+}
+
+// GetLayerIndex of the element
+func (r *RedZone) GetLayerIndex() int {
+	return constants.ZoneLayerIdx
+}
+
+// GetPosition of the element
+func (r *RedZone) GetPosition() interfaces.Location {
+	return r.position
+}
+
+// InitRedZone of the maze
+func InitRedZone(x, y int) *RedZone {
+	return &RedZone{
+		position: structures.InitPosition(x, y),
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tunnel represents an invisible zone where ghosts slow down
+type Tunnel struct {
+	position    interfaces.Location
+	allowsTurns bool
+}
+
+// Draw nothing, tunnels look like empty space
+func (t *Tunnel) Draw(screen *ebiten.Image, x, y int) {}
+
+// GetSprite of the element
+func (t *Tunnel) GetSprite() *ebiten.Image {
+	return nil
+}
+
+// GetDirection of the element
+func (t *Tunnel) GetDirection() constants.Direction {
+	return constants.DirStatic
+}
+
+// IsMatrixEditable based on the object direction
+func (t *Tunnel) IsMatrixEditable() bool {
+	return false
+}
+
+// CanGhostsGoThrough by any force
+func (t *Tunnel) CanGhostsGoThrough() bool {
+	return true
+}
+
+// GetLayerIndex of the element
+func (t *Tunnel) GetLayerIndex() int {
+	return constants.ZoneLayerIdx
+}
+
+// GetPosition of the element
+func (t *Tunnel) GetPosition() interfaces.Location {
+	return t.position
+}
+
+// InitTunnel of the maze
+func InitTunnel(x, y int, allowsTurns bool) *Tunnel {
+	return &Tunnel{
+		position:    structures.InitPosition(x, y),
+		allowsTurns: allowsTurns,
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+)
+
+func TestTunnelTurns(t *testing.T) {
+	tests := []struct {
+		name        string
+		allowsTurns bool
+		want        constants.Direction
+	}{
+		{"tunnel", true, constants.DirUp},
+		{"no-turn tunnel", false, constants.DirLeft},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// A crossing with the tunnel in the middle, reached by a ghost going left
+			maze := structures.InitMaze()
+			for row, line := range []string{"#.#", "...", "#.#"} {
+				maze.AddRow(len(line))
+				for col, elem := range line {
+					if elem == '#' {
+						maze.AddElement(row, col, InitWall(col, row, &modules.AssetManager{}))
+					}
+				}
+			}
+			maze.AddElement(1, 1, InitTunnel(1, 1, test.allowsTurns))
+
+			ghost := &Ghost{
+				ctx:       &contexts.GameContext{Maze: maze},
+				position:  structures.InitPosition(1, 1),
+				direction: constants.DirLeft,
+			}
+			maze.AddElement(1, 1, ghost)
+			ghost.AttachCollisionDetector(modules.InitCollisionDetector(ghost, maze))
+
+			ghost.turnTowards(structures.InitPosition(1, 0), false, true)
+			if ghost.direction != test.want {
+				t.Errorf("got direction %v, want %v", ghost.direction, test.want)
+			}
+		})
+	}
+}
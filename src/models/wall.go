@@ -12,13 +12,10 @@ import (
 type Wall struct {
 	position interfaces.Location
 	sprite   *ebiten.Image
-	animator *modules.Animator
 }
 
-// Draw the element to the screen in given position
-func (w *Wall) Draw(screen *ebiten.Image, x, y int) {
-	w.animator.DrawFrame(screen, x, y)
-}
+// Draw nothing, since walls are pre-rendered into the background of the level
+func (w *Wall) Draw(screen *ebiten.Image, x, y int) {}
 
 // GetSprite of the element
 func (w *Wall) GetSprite() *ebiten.Image {
@@ -56,6 +53,5 @@ func InitWall(x, y int, assetManager *modules.AssetManager) *Wall {
 		position: structures.InitPosition(x, y),
 	}
 	wall.sprite = assetManager.WallSprite
-	wall.animator = modules.InitAnimator(&wall)
 	return &wall
 }
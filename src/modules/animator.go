@@ -13,18 +13,20 @@ type Animator struct {
 	object interfaces.GameObject
 }
 
-// DrawFrame of the game object to the specified position in the screen
-func (a *Animator) DrawFrame(screen *ebiten.Image, x, y int) {
-	op := &ebiten.DrawImageOptions{}
-	frame := a.object.GetSprite()
-	if frame == nil {
-		return
-	}
-
+// drawSprite scaled to a tile in the given position, rotating it towards its direction if specified
+func drawSprite(
+	screen *ebiten.Image,
+	op *ebiten.DrawImageOptions,
+	frame *ebiten.Image,
+	x, y int,
+	direction constants.Direction,
+	rotates bool,
+) {
+	op.GeoM.Reset()
 	width, height := frame.Size()
 	op.GeoM.Scale(constants.TileSize/float64(width), constants.TileSize/float64(height))
-	if a.object.IsMatrixEditable() {
-		switch a.object.GetDirection() {
+	if rotates {
+		switch direction {
 		case constants.DirUp:
 			op.GeoM.Translate(-constants.TileSize/2, -constants.TileSize/2)
 			op.GeoM.Rotate(3 * math.Pi / 2)
@@ -43,6 +45,15 @@ func (a *Animator) DrawFrame(screen *ebiten.Image, x, y int) {
 	screen.DrawImage(frame, op)
 }
 
+// DrawFrame of the game object to the specified position in the screen
+func (a *Animator) DrawFrame(screen *ebiten.Image, x, y int) {
+	frame := a.object.GetSprite()
+	if frame == nil {
+		return
+	}
+	drawSprite(screen, &ebiten.DrawImageOptions{}, frame, x, y, a.object.GetDirection(), a.object.IsMatrixEditable())
+}
+
 // InitAnimator instantiates the animator linked to a game object
 func InitAnimator(object interfaces.GameObject) *Animator {
 	animator := Animator{
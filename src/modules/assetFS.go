@@ -0,0 +1,104 @@
+package modules
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"io/fs"
+	"os"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// AssetFS looks for the assets of the game in an optional override directory before the ones
+// embedded in the binary, so they can be modded without building the game again
+type AssetFS struct {
+	override fs.FS
+	embedded fs.FS
+}
+
+// Open an asset, reporting its name if it cannot be found anywhere
+func (a *AssetFS) Open(name string) (fs.File, error) {
+	if a.override != nil {
+		f, err := a.override.Open(name)
+		if err == nil || !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+	}
+	f, err := a.embedded.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("Missing asset %s: %w", name, err)
+	}
+	return f, err
+}
+
+// ReadFile of an asset
+func (a *AssetFS) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(a, name)
+}
+
+// ReadDir of the assets, merging the override directory with the embedded one
+func (a *AssetFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(a.embedded, name)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if a.override != nil {
+		overrides, overrideErr := fs.ReadDir(a.override, name)
+		if overrideErr != nil && !errors.Is(overrideErr, fs.ErrNotExist) {
+			return nil, overrideErr
+		}
+		if overrideErr == nil {
+			err = nil
+			found := make(map[string]bool)
+			for _, entry := range entries {
+				found[entry.Name()] = true
+			}
+			for _, entry := range overrides {
+				if !found[entry.Name()] {
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Missing asset directory %s: %w", name, err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+// LoadImage asset in any of the registered image formats
+func (a *AssetFS) LoadImage(name string) (*ebiten.Image, error) {
+	f, err := a.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid image %s: %w", name, err)
+	}
+	return ebiten.NewImageFromImage(img), nil
+}
+
+// InitAssetFS with the embedded assets, overridden by the ones in the given directory if any
+func InitAssetFS(embedded fs.FS, overrideDir string) (*AssetFS, error) {
+	assets := AssetFS{embedded: embedded}
+	if overrideDir != "" {
+		info, err := os.Stat(overrideDir)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("Assets override %s is not a directory", overrideDir)
+		}
+		assets.override = os.DirFS(overrideDir)
+	}
+	return &assets, nil
+}
@@ -1,72 +1,122 @@
 package modules
 
 import (
-	"fmt"
+	"image/color"
 
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/golang/freetype/truetype"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
+	"golang.org/x/image/font"
 )
 
 // AssetManager for all shared assets in the game
 type AssetManager struct {
 	PacmanSprites     map[string]*structures.SpriteSequence
 	WallSprite        *ebiten.Image
+	WallPieces        []*ebiten.Image
 	BarsSprite        *ebiten.Image
 	PelletSprite      *ebiten.Image
 	PowerPelletSprite *ebiten.Image
+	MenuScreen        *ebiten.Image
+	OverScreen        *ebiten.Image
+	Background        color.Color
+	FontFace          font.Face
+	ghostSprites      map[string]*structures.SpriteSequence
 }
 
-// NewAssetManager for the game
-func NewAssetManager() (*AssetManager, error) {
-	am := &AssetManager{
-		PacmanSprites: make(map[string]*structures.SpriteSequence),
+// GhostSprites of the given type of ghost, with animations of its own
+func (am *AssetManager) GhostSprites(ghostType constants.GhostType) map[string]*structures.SpriteSequence {
+	sprites := make(map[string]*structures.SpriteSequence)
+	for _, category := range ghostCategories {
+		sprites[category] = am.ghostSprites[ghostSpriteName(ghostType, category)].Clone()
 	}
+	for _, category := range sharedGhostCategories {
+		sprites[category] = am.ghostSprites[ghostSpriteName("", category)].Clone()
+	}
+	return sprites
+}
 
-	wallSprite, _, err := ebitenutil.NewImageFromFile("assets/wall.png")
-	if err != nil {
-		return nil, err
+// TileSprite that represents a tile of a level file, or nil for the tiles without a sprite
+func (am *AssetManager) TileSprite(tile rune) *ebiten.Image {
+	switch {
+	case isWallTile(tile):
+		return am.WallSprite
+	case tile == '|':
+		return am.BarsSprite
+	case tile == '@':
+		return am.PowerPelletSprite
+	case isPelletTile(tile):
+		return am.PelletSprite
+	case tile == 'S':
+		return am.PacmanSprites["alive"].GetCurrentFrame()
+	default:
+		return nil
 	}
+}
 
-	barsSprite, _, err := ebitenutil.NewImageFromFile("assets/bars.png")
-	if err != nil {
-		return nil, err
+// loadFont of the skin, being the one bundled with the game if it does not have any
+func loadFont(assets *AssetFS, skin *structures.SkinManifest) (font.Face, error) {
+	dat := fonts.PressStart2P_ttf
+	if skin.Font != "" {
+		var err error
+		if dat, err = assets.ReadFile(skin.Font); err != nil {
+			return nil, err
+		}
 	}
 
-	pelletSprite, _, err := ebitenutil.NewImageFromFile("assets/pellet.png")
+	tt, err := truetype.Parse(dat)
 	if err != nil {
 		return nil, err
 	}
+	return truetype.NewFace(tt, &truetype.Options{
+		Size: skin.FontSize, DPI: 72, Hinting: font.HintingFull,
+	}), nil
+}
 
-	powerPelletSprite, _, err := ebitenutil.NewImageFromFile("assets/power-pellet.png")
+// NewAssetManager for the game with the assets of the given skin
+func NewAssetManager(assets *AssetFS, skinName string) (*AssetManager, error) {
+	skin, err := LoadSkin(assets, skinName)
 	if err != nil {
 		return nil, err
 	}
 
-	aliveSrc := []string{
-		"assets/pacman/pacman-1.png",
-		"assets/pacman/pacman-2.png",
-		"assets/pacman/pacman-3.png",
+	am := &AssetManager{
+		PacmanSprites: make(map[string]*structures.SpriteSequence),
+		ghostSprites:  make(map[string]*structures.SpriteSequence),
 	}
-	deathSrc := make([]string, 11)
-	for i := 0; i < 11; i++ {
-		deathSrc[i] = fmt.Sprintf("assets/pacman/death-%d.png", i+1)
+	sprites := skin.Sprites
+	for _, ghostType := range skinGhosts {
+		for _, category := range ghostCategories {
+			name := ghostSpriteName(ghostType, category)
+			am.ghostSprites[name] = sprites[name]
+		}
+	}
+	for _, category := range sharedGhostCategories {
+		name := ghostSpriteName("", category)
+		am.ghostSprites[name] = sprites[name]
 	}
 
-	aliveSprites, err := structures.InitSpriteSequence(aliveSrc)
-	if err != nil {
+	if am.Background, err = parseColor(skin.Manifest.Background); err != nil {
 		return nil, err
 	}
-	deadSprites, err := structures.InitSpriteSequence(deathSrc)
+	wallColor, err := parseColor(skin.Manifest.WallColor)
 	if err != nil {
 		return nil, err
 	}
+	am.WallPieces = loadWallPieces(sprites, wallColor)
+	if am.FontFace, err = loadFont(assets, skin.Manifest); err != nil {
+		return nil, err
+	}
 
-	am.WallSprite = wallSprite
-	am.PelletSprite = pelletSprite
-	am.PowerPelletSprite = powerPelletSprite
-	am.BarsSprite = barsSprite
-	am.PacmanSprites["alive"] = aliveSprites
-	am.PacmanSprites["dead"] = deadSprites
+	am.WallSprite = sprites["wall"].GetCurrentFrame()
+	am.PelletSprite = sprites["pellet"].GetCurrentFrame()
+	am.PowerPelletSprite = sprites["powerPellet"].GetCurrentFrame()
+	am.BarsSprite = sprites["bars"].GetCurrentFrame()
+	am.MenuScreen = sprites["menuScreen"].GetCurrentFrame()
+	am.OverScreen = sprites["overScreen"].GetCurrentFrame()
+	am.PacmanSprites["alive"] = sprites["pacman"]
+	am.PacmanSprites["dead"] = sprites["pacmanDeath"]
 	return am, nil
 }
@@ -0,0 +1,115 @@
+package modules
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// Time to wait before checking again on a sound that should have finished already
+const audioCheckInterval = 10 * time.Millisecond
+
+// SoundHandle of a sound being played, which can be stopped or waited for
+type SoundHandle struct {
+	effect    constants.SoundEffect
+	player    *audio.Player
+	scheduler *AudioScheduler
+	done      chan struct{}
+}
+
+// Done channel that is closed once the sound finishes or is stopped
+func (h *SoundHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Stop the sound right away
+func (h *SoundHandle) Stop() {
+	h.player.Pause()
+	h.scheduler.finish(h)
+}
+
+// Wait until the sound finishes, stopping it if the context is cancelled first
+func (h *SoundHandle) Wait(ctx context.Context) error {
+	select {
+	case <-h.done:
+		return nil
+	case <-ctx.Done():
+		h.Stop()
+		return ctx.Err()
+	}
+}
+
+// AudioScheduler tracks when every sound finishes through timers set to its expected end,
+// so that nothing has to keep polling the players
+type AudioScheduler struct {
+	mutex    sync.Mutex
+	timers   map[*SoundHandle]*time.Timer
+	onFinish func(handle *SoundHandle)
+}
+
+// schedule a check of the sound once the given time has passed
+func (a *AudioScheduler) schedule(handle *SoundHandle, after time.Duration) {
+	if after < audioCheckInterval {
+		after = audioCheckInterval
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if _, ok := a.timers[handle]; ok {
+		a.timers[handle] = time.AfterFunc(after, func() {
+			a.check(handle)
+		})
+	}
+}
+
+// check whether the sound finished, rescheduling it if the player is lagging behind
+func (a *AudioScheduler) check(handle *SoundHandle) {
+	if handle.player.IsPlaying() {
+		a.schedule(handle, audioCheckInterval)
+		return
+	}
+	a.finish(handle)
+}
+
+// finish the sound once, whether it ended or was stopped
+func (a *AudioScheduler) finish(handle *SoundHandle) {
+	a.mutex.Lock()
+	timer, ok := a.timers[handle]
+	if ok && timer != nil {
+		timer.Stop()
+	}
+	delete(a.timers, handle)
+	a.mutex.Unlock()
+
+	if ok {
+		close(handle.done)
+		a.onFinish(handle)
+	}
+}
+
+// Track a sound that has just started to play and is expected to last the given time
+func (a *AudioScheduler) Track(effect constants.SoundEffect, player *audio.Player, duration time.Duration) *SoundHandle {
+	handle := &SoundHandle{
+		effect:    effect,
+		player:    player,
+		scheduler: a,
+		done:      make(chan struct{}),
+	}
+
+	a.mutex.Lock()
+	a.timers[handle] = nil
+	a.mutex.Unlock()
+	a.schedule(handle, duration-player.Current())
+	return handle
+}
+
+// InitAudioScheduler that calls the given function whenever a sound finishes
+func InitAudioScheduler(onFinish func(handle *SoundHandle)) *AudioScheduler {
+	return &AudioScheduler{
+		timers:   make(map[*SoundHandle]*time.Timer),
+		onFinish: onFinish,
+	}
+}
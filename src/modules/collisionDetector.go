@@ -13,15 +13,37 @@ type CollisionDetector struct {
 	maze   *structures.Maze
 }
 
-// ViableTiles by direction to take from the current movable object's position
-func (c *CollisionDetector) ViableTiles(blockReverse bool) map[constants.Direction]*structures.Position {
+func (c *CollisionDetector) zonesAllowTurn(zones []interfaces.Zone, direction constants.Direction) bool {
+	for _, zone := range zones {
+		if !zone.AllowsTurn(c.source.GetDirection(), direction) {
+			return false
+		}
+	}
+	return true
+}
+
+// ViableTiles by direction to take from the current movable object's position.
+// Zone restrictions of the current tile are only applied when respectZones is set
+func (c *CollisionDetector) ViableTiles(blockReverse, respectZones bool) map[constants.Direction]*structures.Position {
 	from := c.source.GetPosition()
 	cols, rows := c.maze.Dimensions()
+	var zones []interfaces.Zone
+	if respectZones {
+		for _, obj := range c.maze.ElementsAt(from.X(), from.Y()) {
+			if zone, ok := obj.(interfaces.Zone); ok {
+				zones = append(zones, zone)
+			}
+		}
+	}
+
 	viableTiles := make(map[constants.Direction]*structures.Position)
 	for _, direction := range constants.PossibleDirections {
 		if blockReverse && direction.IsOpposite(c.source.GetDirection()) {
 			continue
 		}
+		if !c.zonesAllowTurn(zones, direction) {
+			continue
+		}
 		toX := utils.Mod(from.X()+direction.X, cols)
 		toY := utils.Mod(from.Y()+direction.Y, rows)
 		targets := c.maze.ElementsAt(toX, toY)
@@ -0,0 +1,90 @@
+package modules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+)
+
+func validateDifficulty(difficulty *structures.Difficulty) error {
+	if difficulty.Lives <= 0 {
+		return fmt.Errorf("Difficulty %q must have at least one life", difficulty.Name)
+	}
+	if len(difficulty.GlobalPelletLimits) == 0 {
+		return fmt.Errorf("Difficulty %q must have global pellet limits", difficulty.Name)
+	}
+	if len(difficulty.Levels) == 0 || difficulty.Levels[0].FromLevel != 1 {
+		return fmt.Errorf("Difficulty %q must have settings starting from level 1", difficulty.Name)
+	}
+	for i := 1; i < len(difficulty.Levels); i++ {
+		if difficulty.Levels[i].FromLevel <= difficulty.Levels[i-1].FromLevel {
+			return fmt.Errorf("Difficulty %q must have its level settings sorted", difficulty.Name)
+		}
+	}
+	for i := range difficulty.Levels {
+		level := &difficulty.Levels[i]
+		if err := validateLevelSettings(level); err != nil {
+			return fmt.Errorf("Difficulty %q from level %d: %v", difficulty.Name, level.FromLevel, err)
+		}
+	}
+	return nil
+}
+
+// validateLevelSettings so that objects always move and the modes and power pellets always last
+func validateLevelSettings(level *structures.LevelSettings) error {
+	if len(level.ModeDurations) == 0 {
+		return errors.New("Mode durations must not be empty")
+	}
+	if level.PowerPelletDuration <= 0 {
+		return errors.New("Power pellet duration must be positive")
+	}
+	speeds := level.Speeds
+	for _, speed := range []float64{
+		speeds.Pacman,
+		speeds.PacmanEating,
+		speeds.PowerPacman,
+		speeds.PowerPacmanEating,
+		speeds.Ghost,
+		speeds.GhostTunnel,
+		speeds.ElroyGhost1,
+		speeds.ElroyGhost2,
+		speeds.FleeingGhost,
+		speeds.EatenGhost,
+	} {
+		if speed <= 0 {
+			return errors.New("Speeds must be positive")
+		}
+	}
+	return nil
+}
+
+// LoadDifficulties from a data file of the assets, keeping the order in which they are declared
+func LoadDifficulties(assets *AssetFS, file string) ([]*structures.Difficulty, error) {
+	dat, err := assets.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var difficulties []*structures.Difficulty
+	if err := json.Unmarshal(dat, &difficulties); err != nil {
+		return nil, err
+	}
+	for _, difficulty := range difficulties {
+		if err := validateDifficulty(difficulty); err != nil {
+			return nil, err
+		}
+	}
+	return difficulties, nil
+}
+
+// FindDifficulty by name among the loaded ones
+func FindDifficulty(difficulties []*structures.Difficulty, name string) (*structures.Difficulty, error) {
+	for _, difficulty := range difficulties {
+		if difficulty.Name == name {
+			return difficulty, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown difficulty %q", name)
+}
@@ -0,0 +1,120 @@
+package modules
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/utils"
+)
+
+// Director adapts the difficulty of a level to the performance of the player.
+// A positive pressure means the player is doing well, so ghosts get tougher
+type Director struct {
+	mutex          sync.Mutex
+	enabled        bool
+	pressure       float64
+	deaths         int
+	ghostsEaten    int
+	pellets        int
+	lastPelletAt   time.Time
+	pelletInterval float64
+	lastEvaluation time.Time
+}
+
+// PelletEaten by the player
+func (d *Director) PelletEaten() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	now := time.Now()
+	if d.pellets > 0 {
+		d.pelletInterval += now.Sub(d.lastPelletAt).Seconds()
+	}
+	d.pellets++
+	d.lastPelletAt = now
+}
+
+// GhostEaten by the player
+func (d *Director) GhostEaten() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.ghostsEaten++
+}
+
+// LifeLost by the player
+func (d *Director) LifeLost() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.deaths++
+}
+
+// Evaluate the performance of the player since the last evaluation and adjust the pressure
+func (d *Director) Evaluate() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if !d.enabled || time.Now().Sub(d.lastEvaluation).Seconds() < constants.DirectorInterval {
+		return
+	}
+
+	delta := 0.1*float64(d.ghostsEaten) - 0.4*float64(d.deaths)
+	if d.pellets > 1 {
+		averageInterval := d.pelletInterval / float64(d.pellets-1)
+		if averageInterval < constants.FastPelletInterval {
+			delta += 0.1
+		} else if averageInterval > constants.SlowPelletInterval {
+			delta -= 0.1
+		}
+	} else if time.Now().Sub(d.lastPelletAt).Seconds() > constants.SlowPelletInterval {
+		delta -= 0.1
+	}
+
+	pressure := utils.Clamp(d.pressure+delta, -1, 1)
+	if pressure != d.pressure {
+		log.Printf(
+			"Director: pressure %.2f -> %.2f (deaths: %d, ghosts eaten: %d, pellets: %d) "+
+				"ghost speed x%.2f, frightened time x%.2f, random turns %+.2f",
+			d.pressure, pressure, d.deaths, d.ghostsEaten, d.pellets,
+			1+constants.MaxGhostSpeedAdjustment*pressure,
+			1-constants.MaxFrightenedAdjustment*pressure,
+			-constants.MaxRandomTurnAdjustment*pressure,
+		)
+	}
+	d.pressure = pressure
+	d.deaths = 0
+	d.ghostsEaten = 0
+	d.pellets = 0
+	d.pelletInterval = 0
+	d.lastEvaluation = time.Now()
+}
+
+// GhostSpeed adjusted to the current pressure
+func (d *Director) GhostSpeed(speed float64) float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return speed * (1 + constants.MaxGhostSpeedAdjustment*d.pressure)
+}
+
+// FrightenedTime adjusted to the current pressure
+func (d *Director) FrightenedTime(seconds float64) float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return seconds * (1 - constants.MaxFrightenedAdjustment*d.pressure)
+}
+
+// RandomTurnChance of chasing ghosts adjusted to the current pressure
+func (d *Director) RandomTurnChance(chance float64) float64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return utils.Clamp(chance-constants.MaxRandomTurnAdjustment*d.pressure, 0, 1)
+}
+
+// InitDirector which only adjusts the difficulty when enabled
+func InitDirector(enabled bool) *Director {
+	return &Director{
+		enabled:        enabled,
+		pressure:       0,
+		lastPelletAt:   time.Now(),
+		lastEvaluation: time.Now(),
+	}
+}
@@ -0,0 +1,155 @@
+package modules
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+)
+
+// tiles that are unique in a level
+var uniqueTiles = []rune{'S', 'G', 'B', 'P', 'I', 'C'}
+
+func isWallTile(tile rune) bool {
+	return tile == '#' || tile == 'B' || tile == 'P' || tile == 'I' || tile == 'C'
+}
+
+func isPelletTile(tile rune) bool {
+	return tile == '.' || tile == '@' || tile == 'R'
+}
+
+// reachableTiles from the given tile, wrapping around the borders of the level
+func reachableTiles(lines []string, fromX, fromY int, throughBars bool) map[[2]int]bool {
+	rows, cols := len(lines), len(lines[0])
+	visited := map[[2]int]bool{{fromX, fromY}: true}
+	queue := [][2]int{{fromX, fromY}}
+	for len(queue) > 0 {
+		tile := queue[0]
+		queue = queue[1:]
+		for _, d := range constants.PossibleDirections {
+			next := [2]int{(tile[0] + d.X + cols) % cols, (tile[1] + d.Y + rows) % rows}
+			elem := rune(lines[next[1]][next[0]])
+			if visited[next] || isWallTile(elem) || (elem == '|' && !throughBars) {
+				continue
+			}
+			visited[next] = true
+			queue = append(queue, next)
+		}
+	}
+	return visited
+}
+
+// ValidateLevel layout, making sure it can be played: it must have a single PacMan start,
+// ghost home and ghost base of each kind, bars to leave home and every pellet reachable
+func ValidateLevel(lines []string) error {
+	if len(lines) != constants.VerticalTiles {
+		return fmt.Errorf("Level must be %dx%d tiles", constants.HorizontalTiles, constants.VerticalTiles)
+	}
+	counts := make(map[rune]int)
+	for _, line := range lines {
+		if len(line) != constants.HorizontalTiles {
+			return fmt.Errorf("Level must be %dx%d tiles", constants.HorizontalTiles, constants.VerticalTiles)
+		}
+		for _, tile := range line {
+			counts[tile]++
+		}
+	}
+	for _, tile := range uniqueTiles {
+		if counts[tile] != 1 {
+			return fmt.Errorf("Need exactly one %c tile", tile)
+		}
+	}
+	if counts['|'] == 0 {
+		return errors.New("Need at least one | tile")
+	}
+	if counts['.']+counts['@']+counts['R'] == 0 {
+		return errors.New("Need at least one pellet")
+	}
+
+	var startX, startY, homeX, homeY int
+	for y, line := range lines {
+		for x, tile := range line {
+			if tile == 'S' {
+				startX, startY = x, y
+			} else if tile == 'G' {
+				homeX, homeY = x, y
+			}
+		}
+	}
+
+	reachable := reachableTiles(lines, startX, startY, false)
+	for y, line := range lines {
+		for x, tile := range line {
+			if isPelletTile(tile) && !reachable[[2]int{x, y}] {
+				return fmt.Errorf("Pellet at %d,%d is unreachable", x, y)
+			}
+		}
+	}
+	if !reachableTiles(lines, homeX, homeY, true)[[2]int{startX, startY}] {
+		return errors.New("Ghosts cannot leave home")
+	}
+	return nil
+}
+
+// ListLevels in a directory of the assets, sorted by name
+func ListLevels(assets *AssetFS, dir string) ([]string, error) {
+	files, err := assets.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]string, 0, len(files))
+	for _, file := range files {
+		if !file.IsDir() && filepath.Ext(file.Name()) == ".txt" {
+			levels = append(levels, path.Join(dir, file.Name()))
+		}
+	}
+	sort.Strings(levels)
+	return levels, nil
+}
+
+// LoadMaze from a level file. Absolute paths are read from disk, while the rest are looked up
+// among the assets, so that stray files in the working directory never shadow them
+func LoadMaze(assets *AssetFS, file string) ([]string, error) {
+	var dat []byte
+	var err error
+	if filepath.IsAbs(file) {
+		dat, err = ioutil.ReadFile(file)
+	} else {
+		dat, err = assets.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]string, 0, constants.VerticalTiles)
+	input := bufio.NewScanner(bytes.NewReader(dat))
+	for input.Scan() {
+		lines = append(lines, input.Text())
+	}
+	return lines, input.Err()
+}
+
+// SaveMaze to a level file
+func SaveMaze(lines []string, file string) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	writer := bufio.NewWriter(f)
+	for _, line := range lines {
+		if _, err := writer.WriteString(line + "\n"); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
@@ -0,0 +1,63 @@
+package modules
+
+import (
+	"strings"
+	"testing"
+)
+
+// setTile of a copy of the level
+func setTile(lines []string, x, y int, tile rune) []string {
+	edited := append([]string(nil), lines...)
+	row := []rune(edited[y])
+	row[x] = tile
+	edited[y] = string(row)
+	return edited
+}
+
+// findTile in the level, failing the test if there is none
+func findTile(t *testing.T, lines []string, tile rune) (int, int) {
+	for y, line := range lines {
+		if x := strings.IndexRune(line, tile); x >= 0 {
+			return x, y
+		}
+	}
+	t.Fatalf("No %c tile in the level", tile)
+	return 0, 0
+}
+
+func TestValidateLevel(t *testing.T) {
+	lines, err := InitMazeGenerator(1).Generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pelletX, pelletY := findTile(t, lines, '.')
+	barsX, barsY := findTile(t, lines, '|')
+	homeX, homeY := findTile(t, lines, 'G')
+
+	tests := []struct {
+		name  string
+		lines []string
+		valid bool
+	}{
+		{"generated maze", lines, true},
+		{"missing row", lines[1:], false},
+		{"short row", append([]string{lines[0][1:]}, lines[1:]...), false},
+		{"two starts", setTile(lines, pelletX, pelletY, 'S'), false},
+		{"two homes", setTile(lines, pelletX, pelletY, 'G'), false},
+		{"missing ghost base", setTile(lines, 2, 2, '#'), false},
+		{"no bars", setTile(lines, barsX, barsY, '#'), false},
+		{"pellet inside the house", setTile(lines, homeX+1, homeY, '.'), false},
+		{"house closed", setTile(setTile(lines, barsX, barsY, '#'), pelletX, pelletY, '|'), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateLevel(test.lines)
+			if test.valid && err != nil {
+				t.Errorf("got error %v, want a valid level", err)
+			} else if !test.valid && err == nil {
+				t.Error("got a valid level, want an error")
+			}
+		})
+	}
+}
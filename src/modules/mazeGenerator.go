@@ -0,0 +1,284 @@
+package modules
+
+import (
+	"math/rand"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+)
+
+// Layout of the generated mazes. Corridors join the nodes of a lattice placed on odd
+// coordinates, so the maze is always carved with one tile wide corridors
+const (
+	mazeCols      = constants.HorizontalTiles
+	mazeRows      = constants.VerticalTiles
+	mazeCenter    = mazeCols / 2
+	houseTop      = mazeRows/2 - 1
+	houseBottom   = mazeRows/2 + 1
+	houseLeft     = mazeCenter - 4
+	houseRight    = mazeCenter + 4
+	ringTop       = houseTop - 1
+	ringBottom    = houseBottom + 1
+	ringLeft      = houseLeft - 2
+	tunnelRow     = mazeRows / 2
+	tunnelLength  = 5
+	startRow      = ringBottom + 4
+	powerPelletX  = 1
+	powerPelletY1 = 3
+	powerPelletY2 = mazeRows - 4
+)
+
+type mazeNode struct {
+	x int
+	y int
+}
+
+type mazeEdge struct {
+	from mazeNode
+	to   mazeNode
+}
+
+// MazeGenerator of symmetric and fully connected mazes without dead ends
+type MazeGenerator struct {
+	random *rand.Rand
+	nodes  map[mazeNode]bool
+	edges  map[mazeEdge]bool
+	fixed  map[mazeEdge]bool
+}
+
+func newMazeEdge(a, b mazeNode) mazeEdge {
+	if b.y < a.y || (b.y == a.y && b.x < a.x) {
+		a, b = b, a
+	}
+	return mazeEdge{from: a, to: b}
+}
+
+func (m *MazeGenerator) isTunnelNode(n mazeNode) bool {
+	return n.y == tunnelRow && n.x < ringLeft
+}
+
+func (m *MazeGenerator) isHouseNode(n mazeNode) bool {
+	return n.y > ringTop && n.y < ringBottom && n.x > ringLeft
+}
+
+// possibleEdges of a node within the left half of the maze, including the center column
+func (m *MazeGenerator) possibleEdges(n mazeNode) []mazeEdge {
+	edges := make([]mazeEdge, 0, 4)
+	for _, d := range constants.PossibleDirections {
+		neighbor := mazeNode{x: n.x + 2*d.X, y: n.y + 2*d.Y}
+		if !m.nodes[neighbor] {
+			continue
+		}
+		// Tunnels are closed corridors that can only be entered from their ends
+		if d.Y != 0 && (m.isTunnelNode(n) || m.isTunnelNode(neighbor)) {
+			continue
+		}
+		edges = append(edges, newMazeEdge(n, neighbor))
+	}
+	return edges
+}
+
+// degree of a node once the left half is mirrored into the right half
+func (m *MazeGenerator) degree(n mazeNode) int {
+	degree := 0
+	for _, edge := range m.possibleEdges(n) {
+		if !m.edges[edge] {
+			continue
+		}
+		degree++
+		// Horizontal edges of the center column are mirrored into a second one
+		if n.x == mazeCenter && edge.from.y == edge.to.y {
+			degree++
+		}
+	}
+	// The leftmost tunnel node wraps around to the right side of the maze
+	if n.x == 1 && n.y == tunnelRow {
+		degree++
+	}
+	return degree
+}
+
+func (m *MazeGenerator) addFixedCorridor(from, to mazeNode) {
+	step := mazeNode{x: 0, y: 0}
+	if to.x > from.x {
+		step.x = 2
+	} else if to.y > from.y {
+		step.y = 2
+	}
+	for n := from; n != to; {
+		next := mazeNode{x: n.x + step.x, y: n.y + step.y}
+		m.fixed[newMazeEdge(n, next)] = true
+		n = next
+	}
+}
+
+func (m *MazeGenerator) initLattice() {
+	for y := 1; y < mazeRows-1; y += 2 {
+		for x := 1; x <= mazeCenter; x += 2 {
+			n := mazeNode{x: x, y: y}
+			if !m.isHouseNode(n) {
+				m.nodes[n] = true
+			}
+		}
+	}
+
+	// Corridors around the ghost house, leading to the tunnel and through the start tile
+	m.addFixedCorridor(mazeNode{x: ringLeft, y: ringTop}, mazeNode{x: mazeCenter, y: ringTop})
+	m.addFixedCorridor(mazeNode{x: ringLeft, y: ringBottom}, mazeNode{x: mazeCenter, y: ringBottom})
+	m.addFixedCorridor(mazeNode{x: ringLeft, y: ringTop}, mazeNode{x: ringLeft, y: ringBottom})
+	m.addFixedCorridor(mazeNode{x: 1, y: tunnelRow}, mazeNode{x: ringLeft, y: tunnelRow})
+	m.addFixedCorridor(mazeNode{x: mazeCenter - 2, y: startRow}, mazeNode{x: mazeCenter, y: startRow})
+}
+
+// connect every node with a random spanning tree that contains the fixed corridors
+func (m *MazeGenerator) connect() {
+	parents := make(map[mazeNode]mazeNode)
+	var find func(n mazeNode) mazeNode
+	find = func(n mazeNode) mazeNode {
+		parent, ok := parents[n]
+		if !ok || parent == n {
+			return n
+		}
+		root := find(parent)
+		parents[n] = root
+		return root
+	}
+	union := func(edge mazeEdge) bool {
+		a, b := find(edge.from), find(edge.to)
+		if a == b {
+			return false
+		}
+		parents[a] = b
+		return true
+	}
+
+	for edge := range m.fixed {
+		union(edge)
+		m.edges[edge] = true
+	}
+
+	candidates := make([]mazeEdge, 0)
+	for _, n := range m.sortedNodes() {
+		for _, edge := range m.possibleEdges(n) {
+			if edge.from == n && !m.fixed[edge] {
+				candidates = append(candidates, edge)
+			}
+		}
+	}
+	m.random.Shuffle(len(candidates), func(i, j int) {
+		candidates[i], candidates[j] = candidates[j], candidates[i]
+	})
+	for _, edge := range candidates {
+		if union(edge) {
+			m.edges[edge] = true
+		}
+	}
+}
+
+// removeDeadEnds by connecting every dead end with one of its neighbors, preferably another dead end
+func (m *MazeGenerator) removeDeadEnds() {
+	for _, n := range m.sortedNodes() {
+		if m.degree(n) > 1 {
+			continue
+		}
+
+		options := make([]mazeEdge, 0)
+		preferred := make([]mazeEdge, 0)
+		for _, edge := range m.possibleEdges(n) {
+			if m.edges[edge] {
+				continue
+			}
+			options = append(options, edge)
+			neighbor := edge.from
+			if neighbor == n {
+				neighbor = edge.to
+			}
+			if m.degree(neighbor) <= 1 {
+				preferred = append(preferred, edge)
+			}
+		}
+		if len(preferred) > 0 {
+			options = preferred
+		}
+		if len(options) > 0 {
+			m.edges[options[m.random.Intn(len(options))]] = true
+		}
+	}
+}
+
+// sortedNodes to keep the generation deterministic given a seed
+func (m *MazeGenerator) sortedNodes() []mazeNode {
+	nodes := make([]mazeNode, 0, len(m.nodes))
+	for y := 1; y < mazeRows-1; y += 2 {
+		for x := 1; x <= mazeCenter; x += 2 {
+			if n := (mazeNode{x: x, y: y}); m.nodes[n] {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	return nodes
+}
+
+func (m *MazeGenerator) render() [][]rune {
+	grid := make([][]rune, mazeRows)
+	for y := range grid {
+		grid[y] = make([]rune, mazeCols)
+		for x := range grid[y] {
+			grid[y][x] = '#'
+		}
+	}
+	carve := func(x, y int, tile rune) {
+		grid[y][x] = tile
+		grid[y][mazeCols-1-x] = tile
+	}
+
+	for n := range m.nodes {
+		carve(n.x, n.y, '.')
+	}
+	for edge := range m.edges {
+		carve((edge.from.x+edge.to.x)/2, (edge.from.y+edge.to.y)/2, '.')
+	}
+
+	for x := 0; x < tunnelLength; x++ {
+		carve(x, tunnelRow, 'T')
+	}
+	for x := houseLeft + 1; x < houseRight; x++ {
+		grid[tunnelRow][x] = ' '
+	}
+	grid[houseTop][mazeCenter] = '|'
+	grid[tunnelRow][mazeCenter] = 'G'
+	grid[startRow][mazeCenter] = 'S'
+	carve(powerPelletX, powerPelletY1, '@')
+	carve(powerPelletX, powerPelletY2, '@')
+	grid[2][2] = 'P'
+	grid[2][mazeCols-3] = 'B'
+	grid[mazeRows-3][2] = 'C'
+	grid[mazeRows-3][mazeCols-3] = 'I'
+	return grid
+}
+
+// Generate a maze in the level file format
+func (m *MazeGenerator) Generate() ([]string, error) {
+	m.nodes = make(map[mazeNode]bool)
+	m.edges = make(map[mazeEdge]bool)
+	m.fixed = make(map[mazeEdge]bool)
+	m.initLattice()
+	m.connect()
+	m.removeDeadEnds()
+
+	grid := m.render()
+	lines := make([]string, len(grid))
+	for i, row := range grid {
+		lines[i] = string(row)
+	}
+	if err := ValidateLevel(lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// InitMazeGenerator seeded for reproducibility
+func InitMazeGenerator(seed int64) *MazeGenerator {
+	return &MazeGenerator{
+		random: rand.New(rand.NewSource(seed)),
+	}
+}
@@ -0,0 +1,65 @@
+package modules
+
+import (
+	"reflect"
+	"testing"
+)
+
+// openNeighbors of a tile that PacMan can walk to, wrapping around the borders of the maze
+func openNeighbors(lines []string, x, y int) int {
+	rows, cols := len(lines), len(lines[0])
+	open := 0
+	for _, d := range [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+		tile := rune(lines[(y+d[1]+rows)%rows][(x+d[0]+cols)%cols])
+		if !isWallTile(tile) && tile != '|' {
+			open++
+		}
+	}
+	return open
+}
+
+func TestMazeGenerator(t *testing.T) {
+	for _, seed := range []int64{0, 1, 42, 2021, -7, 1 << 40} {
+		lines, err := InitMazeGenerator(seed).Generate()
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+
+		again, err := InitMazeGenerator(seed).Generate()
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if !reflect.DeepEqual(lines, again) {
+			t.Errorf("seed %d generated two different mazes", seed)
+		}
+
+		if err := ValidateLevel(lines); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+		}
+
+		for y, line := range lines {
+			for x := range line {
+				// Ghost bases are walls placed on one side only
+				tile, mirrored := rune(line[x]), rune(line[len(line)-1-x])
+				if isWallTile(tile) != isWallTile(mirrored) || (!isWallTile(tile) && tile != mirrored) {
+					t.Errorf("seed %d: row %d is not symmetric: %s", seed, y, line)
+					break
+				}
+			}
+		}
+
+		var startX, startY int
+		for y, line := range lines {
+			for x, tile := range line {
+				if tile == 'S' {
+					startX, startY = x, y
+				}
+			}
+		}
+		for tile := range reachableTiles(lines, startX, startY, false) {
+			if open := openNeighbors(lines, tile[0], tile[1]); open < 2 {
+				t.Errorf("seed %d: dead end at %d,%d", seed, tile[0], tile[1])
+			}
+		}
+	}
+}
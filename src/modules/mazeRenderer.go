@@ -0,0 +1,88 @@
+package modules
+
+import (
+	"image/color"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// MazeRenderer draws a maze in layers: walls and bars are cached once, pellets are redrawn
+// only where they changed and PacMan and the ghosts are drawn from a snapshot of the maze
+type MazeRenderer struct {
+	maze     *structures.Maze
+	static   *ebiten.Image
+	pellets  *ebiten.Image
+	eraser   *ebiten.Image
+	drawn    [][]*ebiten.Image
+	current  [][]*ebiten.Image
+	entities []structures.SpriteSnapshot
+	op       ebiten.DrawImageOptions
+}
+
+// Snapshot the maze to be drawn next, which must be called while holding its mutex
+func (r *MazeRenderer) Snapshot() {
+	r.entities = r.maze.Snapshot(r.current, r.entities[:0])
+}
+
+// updatePellets of the cached layer that changed since the last time it was drawn
+func (r *MazeRenderer) updatePellets() {
+	for y, row := range r.current {
+		for x, pellet := range row {
+			if pellet == r.drawn[y][x] {
+				continue
+			}
+			r.op.GeoM.Reset()
+			r.op.GeoM.Translate(float64(x*constants.TileSize), float64(y*constants.TileSize))
+			r.op.CompositeMode = ebiten.CompositeModeClear
+			r.pellets.DrawImage(r.eraser, &r.op)
+			r.op.CompositeMode = ebiten.CompositeModeSourceOver
+			if pellet != nil {
+				drawSprite(r.pellets, &r.op, pellet, x, y, constants.DirStatic, false)
+			}
+			r.drawn[y][x] = pellet
+		}
+	}
+}
+
+// Draw the last snapshot of the maze to the screen
+func (r *MazeRenderer) Draw(screen *ebiten.Image) {
+	r.updatePellets()
+	r.op.GeoM.Reset()
+	screen.DrawImage(r.pellets, &r.op)
+	for _, entity := range r.entities {
+		if entity.Sprite != nil {
+			drawSprite(screen, &r.op, entity.Sprite, entity.X, entity.Y, entity.Direction, entity.Rotates)
+		}
+	}
+	// Walls and bars go on top, like their layers did when every tile was drawn on its own
+	r.op.GeoM.Reset()
+	screen.DrawImage(r.static, &r.op)
+}
+
+// InitMazeRenderer of a recently parsed maze, rendering its walls with the given pieces
+func InitMazeRenderer(maze *structures.Maze, wallPieces []*ebiten.Image) *MazeRenderer {
+	cols, rows := maze.Dimensions()
+	renderer := MazeRenderer{
+		maze:    maze,
+		static:  InitWallTiler(maze, wallPieces).Render(),
+		pellets: ebiten.NewImage(cols*constants.TileSize, rows*constants.TileSize),
+		eraser:  ebiten.NewImage(constants.TileSize, constants.TileSize),
+		drawn:   make([][]*ebiten.Image, rows),
+		current: make([][]*ebiten.Image, rows),
+	}
+	renderer.eraser.Fill(color.White)
+	for y := 0; y < rows; y++ {
+		renderer.drawn[y] = make([]*ebiten.Image, cols)
+		renderer.current[y] = make([]*ebiten.Image, cols)
+		for x := 0; x < cols; x++ {
+			for _, object := range maze.ElementsAt(x, y) {
+				if object.GetLayerIndex() == constants.BarsLayerIdx {
+					drawSprite(renderer.static, &renderer.op, object.GetSprite(), x, y, constants.DirStatic, false)
+				}
+			}
+		}
+	}
+	return &renderer
+}
@@ -0,0 +1,189 @@
+//go:build display
+// +build display
+
+package modules
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// errTestsFinished ends the game loop the benchmarks run in
+var errTestsFinished = errors.New("Tests finished")
+
+// testGame runs the benchmarks inside the game loop, since images can only be drawn once it started
+type testGame struct {
+	m    *testing.M
+	code int
+}
+
+func (g *testGame) Update() error {
+	g.code = g.m.Run()
+	return errTestsFinished
+}
+
+func (g *testGame) Draw(screen *ebiten.Image) {}
+
+func (g *testGame) Layout(outsideWidth, outsideHeight int) (int, int) {
+	return constants.HorizontalTiles * constants.TileSize, constants.VerticalTiles * constants.TileSize
+}
+
+func TestMain(m *testing.M) {
+	game := testGame{m: m}
+	if err := ebiten.RunGame(&game); err != nil && err != errTestsFinished {
+		panic(err)
+	}
+	os.Exit(game.code)
+}
+
+// benchObject stands in for the objects of a level, drawing itself the way they do
+type benchObject struct {
+	position interfaces.Location
+	sprite   *ebiten.Image
+	layer    int
+	animator *Animator
+}
+
+func (o *benchObject) Draw(screen *ebiten.Image, x, y int) {
+	o.animator.DrawFrame(screen, x, y)
+}
+
+func (o *benchObject) GetSprite() *ebiten.Image {
+	return o.sprite
+}
+
+func (o *benchObject) GetDirection() constants.Direction {
+	return constants.DirLeft
+}
+
+func (o *benchObject) IsMatrixEditable() bool {
+	return o.layer != constants.PelletLayerIdx && o.layer != constants.WallLayerIdx
+}
+
+func (o *benchObject) CanGhostsGoThrough() bool {
+	return o.layer != constants.WallLayerIdx
+}
+
+func (o *benchObject) GetLayerIndex() int {
+	return o.layer
+}
+
+func (o *benchObject) GetPosition() interfaces.Location {
+	return o.position
+}
+
+// loadBenchMaze with the shipped level, drawing every tile with the sprite of the default skin
+// and placing the four ghosts at home
+func loadBenchMaze(b *testing.B) (*structures.Maze, *AssetManager) {
+	assets, err := InitAssetFS(os.DirFS(filepath.Join("..", "..", "assets")), "")
+	if err != nil {
+		b.Fatal(err)
+	}
+	am, err := NewAssetManager(assets, constants.DefaultSkin)
+	if err != nil {
+		b.Fatal(err)
+	}
+	lines, err := LoadMaze(assets, constants.DefaultLevelFile)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	layers := map[*ebiten.Image]int{
+		am.WallSprite:        constants.WallLayerIdx,
+		am.BarsSprite:        constants.BarsLayerIdx,
+		am.PelletSprite:      constants.PelletLayerIdx,
+		am.PowerPelletSprite: constants.PelletLayerIdx,
+	}
+	add := func(maze *structures.Maze, x, y int, sprite *ebiten.Image, layer int) {
+		object := benchObject{position: structures.InitPosition(x, y), sprite: sprite, layer: layer}
+		object.animator = InitAnimator(&object)
+		maze.AddElement(y, x, &object)
+	}
+
+	maze := structures.InitMaze()
+	for row, line := range lines {
+		maze.AddRow(len(line))
+		for col, tile := range line {
+			if tile == 'G' {
+				for _, ghostType := range skinGhosts {
+					add(maze, col, row, am.GhostSprites(ghostType)["left"].GetCurrentFrame(), constants.GhostLayerIdx)
+				}
+			} else if sprite := am.TileSprite(tile); sprite != nil {
+				layer, ok := layers[sprite]
+				if !ok {
+					layer = constants.PacmanLayerIdx
+				}
+				add(maze, col, row, sprite, layer)
+			}
+		}
+	}
+	return maze, am
+}
+
+// drawPerTile the way the maze was drawn before the renderer, sorting the objects of every tile
+func drawPerTile(screen *ebiten.Image, maze *structures.Maze) {
+	cols, rows := maze.Dimensions()
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			objects := maze.ElementsAt(x, y)
+			for k := range objects {
+				objects[len(objects)-1-k].Draw(screen, x, y)
+			}
+		}
+	}
+}
+
+func BenchmarkMazeDraw(b *testing.B) {
+	maze, am := loadBenchMaze(b)
+	cols, rows := maze.Dimensions()
+	screen := ebiten.NewImage(cols*constants.TileSize, rows*constants.TileSize)
+
+	b.Run("PerTile", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			drawPerTile(screen, maze)
+		}
+	})
+
+	b.Run("Renderer", func(b *testing.B) {
+		renderer := InitMazeRenderer(maze, am.WallPieces)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			renderer.Snapshot()
+			renderer.Draw(screen)
+		}
+	})
+}
+
+func BenchmarkMazeSnapshot(b *testing.B) {
+	maze, am := loadBenchMaze(b)
+	cols, rows := maze.Dimensions()
+
+	b.Run("GetObjects", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for y := 0; y < rows; y++ {
+				for x := 0; x < cols; x++ {
+					maze.ElementsAt(x, y)
+				}
+			}
+		}
+	})
+
+	b.Run("Snapshot", func(b *testing.B) {
+		renderer := InitMazeRenderer(maze, am.WallPieces)
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			renderer.Snapshot()
+		}
+	})
+}
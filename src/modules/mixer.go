@@ -0,0 +1,188 @@
+package modules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/utils"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+)
+
+// mixerTrack of a player with the gain applied on top of the volume of its bus
+type mixerTrack struct {
+	bus  constants.AudioBus
+	gain float64
+}
+
+// Mixer with the volume of every audio bus, applied to the players as soon as it changes
+type Mixer struct {
+	mutex    sync.Mutex
+	file     string
+	settings *structures.AudioSettings
+	ducking  int
+	players  map[*audio.Player]*mixerTrack
+}
+
+// volume of a bus, which must be called while holding the mutex
+func (m *Mixer) volume(bus constants.AudioBus) float64 {
+	if m.settings.Muted {
+		return 0
+	}
+	volume := m.settings.Master * m.settings.Buses[bus]
+	if bus == constants.SirenBus && m.ducking > 0 {
+		volume *= constants.DuckingVolume
+	}
+	return volume
+}
+
+// apply the volume of every bus to the tracked players, which must be called while holding the mutex
+func (m *Mixer) apply() {
+	for player, track := range m.players {
+		player.SetVolume(m.volume(track.bus) * track.gain)
+	}
+}
+
+func (m *Mixer) save() error {
+	dat, err := json.MarshalIndent(m.settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(m.file, dat, 0644)
+}
+
+// Volume of a bus after applying the master volume, mute and ducking
+func (m *Mixer) Volume(bus constants.AudioBus) float64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.volume(bus)
+}
+
+// Track a player of a bus until it is released, setting its volume with the given gain
+func (m *Mixer) Track(player *audio.Player, bus constants.AudioBus, gain float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.players[player] = &mixerTrack{bus: bus, gain: gain}
+	player.SetVolume(m.volume(bus) * gain)
+}
+
+// Gain of a tracked player
+func (m *Mixer) Gain(player *audio.Player) float64 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if track, ok := m.players[player]; ok {
+		return track.gain
+	}
+	return 0
+}
+
+// SetGain of a tracked player, used to fade it in or out
+func (m *Mixer) SetGain(player *audio.Player, gain float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if track, ok := m.players[player]; ok {
+		track.gain = utils.Clamp(gain, 0, 1)
+		player.SetVolume(m.volume(track.bus) * track.gain)
+	}
+}
+
+// Release a player that will not be played anymore
+func (m *Mixer) Release(player *audio.Player) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.players, player)
+}
+
+// Duck the siren bus until Unduck is called
+func (m *Mixer) Duck() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.ducking++
+	m.apply()
+}
+
+// Unduck the siren bus once every ducking sound has finished
+func (m *Mixer) Unduck() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.ducking > 0 {
+		m.ducking--
+	}
+	m.apply()
+}
+
+// ChangeMasterVolume by the given amount and save the settings
+func (m *Mixer) ChangeMasterVolume(delta float64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.settings.Master = utils.Clamp(m.settings.Master+delta, 0, 1)
+	m.apply()
+	return m.save()
+}
+
+// ChangeBusVolume of a single bus by the given amount and save the settings
+func (m *Mixer) ChangeBusVolume(bus constants.AudioBus, delta float64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	volume, ok := m.settings.Buses[bus]
+	if !ok {
+		return fmt.Errorf("Unknown audio bus %q", bus)
+	}
+	m.settings.Buses[bus] = utils.Clamp(volume+delta, 0, 1)
+	m.apply()
+	return m.save()
+}
+
+// ToggleMute of every bus and save the settings
+func (m *Mixer) ToggleMute() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.settings.Muted = !m.settings.Muted
+	m.apply()
+	return m.save()
+}
+
+// SoundPack chosen in the settings
+func (m *Mixer) SoundPack() string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.settings.SoundPack
+}
+
+// SetSoundPack to use from now on and save the settings
+func (m *Mixer) SetSoundPack(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.settings.SoundPack = name
+	return m.save()
+}
+
+// LoadMixer with the settings of a data file, starting with the default ones if it does not exist yet
+func LoadMixer(file string) (*Mixer, error) {
+	mixer := Mixer{
+		file:     file,
+		settings: structures.InitAudioSettings(),
+		players:  make(map[*audio.Player]*mixerTrack),
+	}
+
+	dat, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return &mixer, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(dat, mixer.settings); err != nil {
+		return nil, err
+	}
+	// Volumes edited by hand are kept in range, since above 1 they would amplify the sounds
+	mixer.settings.Master = utils.Clamp(mixer.settings.Master, 0, 1)
+	for bus, volume := range mixer.settings.Buses {
+		mixer.settings.Buses[bus] = utils.Clamp(volume, 0, 1)
+	}
+	return &mixer, nil
+}
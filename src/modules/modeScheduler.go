@@ -0,0 +1,84 @@
+package modules
+
+import (
+	"sync"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+)
+
+// ModeScheduler alternates the global mode of all ghosts between scatter and chase
+type ModeScheduler struct {
+	mutex     sync.Mutex
+	durations []float64
+	phase     int
+	elapsed   float64
+	paused    bool
+}
+
+// Mode as the event that makes ghosts enter the current phase
+func (m *ModeScheduler) Mode() constants.StateEvent {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.phase%2 == 0 {
+		return constants.Scatter
+	}
+	return constants.ChasePacman
+}
+
+// Phase index within the table of durations
+func (m *ModeScheduler) Phase() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.phase
+}
+
+// Advance the timer of the current phase and indicate whether the mode changed
+func (m *ModeScheduler) Advance(seconds float64) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	// Ghosts keep chasing forever after the last phase
+	if m.paused || m.phase >= len(m.durations) {
+		return false
+	}
+
+	m.elapsed += seconds
+	if m.elapsed < m.durations[m.phase] {
+		return false
+	}
+	m.elapsed = 0
+	m.phase++
+	return true
+}
+
+// Pause the timer, e.g. while ghosts are frightened
+func (m *ModeScheduler) Pause() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.paused = true
+}
+
+// Resume the timer
+func (m *ModeScheduler) Resume() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.paused = false
+}
+
+// Reset the schedule to its first scatter phase
+func (m *ModeScheduler) Reset() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.phase = 0
+	m.elapsed = 0
+	m.paused = false
+}
+
+// InitModeScheduler given the durations in seconds of each phase
+func InitModeScheduler(durations []float64) *ModeScheduler {
+	return &ModeScheduler{
+		durations: durations,
+		phase:     0,
+		elapsed:   0,
+		paused:    false,
+	}
+}
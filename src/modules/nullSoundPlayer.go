@@ -0,0 +1,48 @@
+package modules
+
+import (
+	"context"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
+)
+
+// nullSound that finishes as soon as it is played
+type nullSound struct {
+	done chan struct{}
+}
+
+func (n *nullSound) Done() <-chan struct{} {
+	return n.done
+}
+
+func (n *nullSound) Stop() {}
+
+func (n *nullSound) Wait(ctx context.Context) error {
+	return nil
+}
+
+func (n *nullSound) Replace(effect constants.SoundEffect, crossfade bool) {}
+
+// NullSoundPlayer plays nothing, for machines without a sound device
+type NullSoundPlayer struct{}
+
+// PlayOnLoop does nothing until the audio is stopped
+func (s *NullSoundPlayer) PlayOnLoop(ctx context.Context, effect constants.SoundEffect) interfaces.LoopingSound {
+	return &nullSound{}
+}
+
+// PlayOnceAndNotify right away since nothing is played
+func (s *NullSoundPlayer) PlayOnceAndNotify(ctx context.Context, effect constants.SoundEffect) interfaces.SoundHandle {
+	done := make(chan struct{})
+	close(done)
+	return &nullSound{done: done}
+}
+
+// PlayOnce does nothing
+func (s *NullSoundPlayer) PlayOnce(effect constants.SoundEffect) {}
+
+// InitNullSoundPlayer that plays nothing
+func InitNullSoundPlayer() *NullSoundPlayer {
+	return &NullSoundPlayer{}
+}
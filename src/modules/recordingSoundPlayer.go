@@ -0,0 +1,112 @@
+package modules
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+)
+
+// recordedLoop records what happens to a sound on loop before passing it on
+type recordedLoop struct {
+	recorder *RecordingSoundPlayer
+	loop     interfaces.LoopingSound
+	mutex    sync.Mutex
+	effect   constants.SoundEffect
+	stopped  bool
+	done     chan struct{}
+}
+
+func (r *recordedLoop) Stop() {
+	r.mutex.Lock()
+	if !r.stopped {
+		r.stopped = true
+		r.recorder.record(r.effect, constants.StoppedLoop)
+		close(r.done)
+	}
+	r.mutex.Unlock()
+	r.loop.Stop()
+}
+
+func (r *recordedLoop) Replace(effect constants.SoundEffect, crossfade bool) {
+	r.mutex.Lock()
+	if !r.stopped {
+		r.effect = effect
+		r.recorder.record(effect, constants.ReplacedLoop)
+	}
+	r.mutex.Unlock()
+	r.loop.Replace(effect, crossfade)
+}
+
+// RecordingSoundPlayer records every sound effect triggered before passing it on to another player
+type RecordingSoundPlayer struct {
+	mutex   sync.Mutex
+	player  interfaces.SoundPlayer
+	logger  *log.Logger
+	started time.Time
+	events  []structures.SoundEvent
+}
+
+func (s *RecordingSoundPlayer) record(effect constants.SoundEffect, action constants.SoundAction) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	event := structures.SoundEvent{Effect: effect, Action: action, At: time.Since(s.started)}
+	s.events = append(s.events, event)
+	if s.logger != nil {
+		s.logger.Printf("%v %s %s", event.At, action, constants.SoundEffectNames[effect])
+	}
+}
+
+// Events recorded so far, in the order they happened
+func (s *RecordingSoundPlayer) Events() []structures.SoundEvent {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	events := make([]structures.SoundEvent, len(s.events))
+	copy(events, s.events)
+	return events
+}
+
+// PlayOnLoop the specified sound effect through the recorded player
+func (s *RecordingSoundPlayer) PlayOnLoop(ctx context.Context, effect constants.SoundEffect) interfaces.LoopingSound {
+	s.record(effect, constants.PlayedOnLoop)
+	loop := &recordedLoop{
+		recorder: s,
+		loop:     s.player.PlayOnLoop(ctx, effect),
+		effect:   effect,
+		done:     make(chan struct{}),
+	}
+	go func() {
+		// Loops stopped by the context are recorded as well
+		select {
+		case <-ctx.Done():
+			loop.Stop()
+		case <-loop.done:
+		}
+	}()
+	return loop
+}
+
+// PlayOnceAndNotify the specified sound effect through the recorded player
+func (s *RecordingSoundPlayer) PlayOnceAndNotify(ctx context.Context, effect constants.SoundEffect) interfaces.SoundHandle {
+	s.record(effect, constants.PlayedOnce)
+	return s.player.PlayOnceAndNotify(ctx, effect)
+}
+
+// PlayOnce the specified sound effect through the recorded player
+func (s *RecordingSoundPlayer) PlayOnce(effect constants.SoundEffect) {
+	s.record(effect, constants.PlayedOnce)
+	s.player.PlayOnce(effect)
+}
+
+// InitRecordingSoundPlayer on top of another player, logging every event if a logger is given
+func InitRecordingSoundPlayer(player interfaces.SoundPlayer, logger *log.Logger) *RecordingSoundPlayer {
+	return &RecordingSoundPlayer{
+		player:  player,
+		logger:  logger,
+		started: time.Now(),
+	}
+}
@@ -0,0 +1,59 @@
+package modules
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+)
+
+// waitForEvents recorded by the player, since loops stopped by their context are recorded later
+func waitForEvents(player *RecordingSoundPlayer, count int) []structures.SoundEvent {
+	deadline := time.Now().Add(time.Second)
+	events := player.Events()
+	for len(events) < count && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		events = player.Events()
+	}
+	return events
+}
+
+func TestRecordingSoundPlayerEvents(t *testing.T) {
+	player := InitRecordingSoundPlayer(InitNullSoundPlayer(), nil)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	player.PlayOnce(constants.MunchEffect)
+	siren := player.PlayOnLoop(ctx, constants.GhostSirenPhase1)
+	siren.Replace(constants.PowerPellet, true)
+	siren.Stop()
+	siren.Stop()
+	<-player.PlayOnceAndNotify(ctx, constants.DyingEffect).Done()
+	player.PlayOnLoop(ctx, constants.Retreating)
+	cancel()
+
+	want := []structures.SoundEvent{
+		{Effect: constants.MunchEffect, Action: constants.PlayedOnce},
+		{Effect: constants.GhostSirenPhase1, Action: constants.PlayedOnLoop},
+		{Effect: constants.PowerPellet, Action: constants.ReplacedLoop},
+		{Effect: constants.PowerPellet, Action: constants.StoppedLoop},
+		{Effect: constants.DyingEffect, Action: constants.PlayedOnce},
+		{Effect: constants.Retreating, Action: constants.PlayedOnLoop},
+		{Effect: constants.Retreating, Action: constants.StoppedLoop},
+	}
+	events := waitForEvents(player, len(want))
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %v", len(events), len(want), events)
+	}
+	for i, event := range events {
+		if event.Effect != want[i].Effect || event.Action != want[i].Action {
+			t.Errorf("event %d: got %s %s, want %s %s", i,
+				event.Action, constants.SoundEffectNames[event.Effect],
+				want[i].Action, constants.SoundEffectNames[want[i].Effect])
+		}
+		if i > 0 && event.At < events[i-1].At {
+			t.Errorf("event %d happened at %v, before the previous one at %v", i, event.At, events[i-1].At)
+		}
+	}
+}
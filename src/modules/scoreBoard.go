@@ -0,0 +1,73 @@
+package modules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+)
+
+// ScoreBoard with the best scores of every game mode, kept apart from each other
+type ScoreBoard struct {
+	mutex  sync.Mutex
+	file   string
+	scores map[string][]uint
+}
+
+// Best score recorded for the game mode
+func (s *ScoreBoard) Best(mode constants.GameMode) uint {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	scores := s.scores[constants.GameModeNames[mode]]
+	if len(scores) == 0 {
+		return 0
+	}
+	return scores[0]
+}
+
+// Record a score of the game mode and save the board, returning whether it is a new high score
+func (s *ScoreBoard) Record(mode constants.GameMode, score uint) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	name := constants.GameModeNames[mode]
+	scores := s.scores[name]
+	isHighScore := len(scores) == 0 || score > scores[0]
+
+	scores = append(scores, score)
+	sort.Slice(scores, func(i, j int) bool {
+		return scores[i] > scores[j]
+	})
+	if len(scores) > constants.MaxHighScores {
+		scores = scores[:constants.MaxHighScores]
+	}
+	s.scores[name] = scores
+
+	dat, err := json.MarshalIndent(s.scores, "", "  ")
+	if err != nil {
+		return isHighScore, err
+	}
+	return isHighScore, ioutil.WriteFile(s.file, dat, 0644)
+}
+
+// LoadScoreBoard from a data file, starting an empty one if the file does not exist yet
+func LoadScoreBoard(file string) (*ScoreBoard, error) {
+	board := ScoreBoard{
+		file:   file,
+		scores: make(map[string][]uint),
+	}
+
+	dat, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return &board, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(dat, &board.scores); err != nil {
+		return nil, err
+	}
+	return &board, nil
+}
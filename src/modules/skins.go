@@ -0,0 +1,226 @@
+package modules
+
+import (
+	"encoding/json"
+	"fmt"
+	"image/color"
+	"log"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Duration in milliseconds of the frames of animations that do not set any
+const defaultFrameDuration = 100
+
+// Sprites every skin can define, besides the ones of each ghost
+var skinSprites = []string{"wall", "bars", "pellet", "powerPellet", "menuScreen", "overScreen", "pacman", "pacmanDeath"}
+
+// Categories of the sprites of every ghost, the first ones being specific to each type of ghost
+var ghostCategories = []string{"left", "right", "down", "up"}
+var sharedGhostCategories = []string{"panic", "flicker", "eaten-left", "eaten-right", "eaten-down", "eaten-up"}
+
+var skinGhosts = []constants.GhostType{constants.Blinky, constants.Pinky, constants.Inky, constants.Clyde}
+
+// ghostSpriteName in the manifests of the skins, where an empty type stands for the sprites shared by every ghost
+func ghostSpriteName(ghostType constants.GhostType, category string) string {
+	if ghostType == "" {
+		return "ghost-" + category
+	}
+	return fmt.Sprintf("ghost-%s-%s", ghostType, category)
+}
+
+// requiredSprites of a complete skin
+func requiredSprites() []string {
+	names := append([]string{}, skinSprites...)
+	for _, ghostType := range skinGhosts {
+		for _, category := range ghostCategories {
+			names = append(names, ghostSpriteName(ghostType, category))
+		}
+	}
+	for _, category := range sharedGhostCategories {
+		names = append(names, ghostSpriteName("", category))
+	}
+	return names
+}
+
+// parseColor in hexadecimal notation, e.g. #1a1a2e
+func parseColor(hex string) (color.RGBA, error) {
+	value, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(strings.TrimPrefix(hex, "#")) != 6 {
+		return color.RGBA{}, fmt.Errorf("Invalid color %q", hex)
+	}
+	return color.RGBA{uint8(value >> 16), uint8(value >> 8), uint8(value), 255}, nil
+}
+
+// Skin with the sprites of its manifest already loaded
+type Skin struct {
+	Manifest *structures.SkinManifest
+	Sprites  map[string]*structures.SpriteSequence
+}
+
+// loadAnimation of a sprite, taking its frames from the atlas or from the image files of the skin
+func loadAnimation(
+	definition *structures.SpriteDefinition,
+	assets *AssetFS,
+	dir string,
+	atlas map[string]*ebiten.Image,
+) (*structures.SpriteSequence, error) {
+	frames := make([]*ebiten.Image, len(definition.Frames))
+	for i, name := range definition.Frames {
+		if frame, ok := atlas[name]; ok {
+			frames[i] = frame
+			continue
+		}
+		frame, err := assets.LoadImage(path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		frames[i] = frame
+	}
+
+	durations := make([]time.Duration, len(frames))
+	if len(definition.Durations) > 0 && len(definition.Durations) != len(frames) {
+		return nil, fmt.Errorf("Expected %d frame durations but got %d", len(frames), len(definition.Durations))
+	}
+	for i := range durations {
+		milliseconds := definition.Duration
+		if len(definition.Durations) > 0 {
+			milliseconds = definition.Durations[i]
+		}
+		if milliseconds <= 0 {
+			milliseconds = defaultFrameDuration
+		}
+		durations[i] = time.Duration(milliseconds) * time.Millisecond
+	}
+	return structures.InitSpriteSequence(frames, durations, definition.Loop), nil
+}
+
+// loadSkin with the name given, resolving the paths of its files relative to its manifest
+func loadSkin(assets *AssetFS, name string) (*Skin, error) {
+	dir := path.Join(constants.SkinsDir, name)
+	dat, err := assets.ReadFile(path.Join(dir, constants.SkinManifest))
+	if err != nil {
+		return nil, err
+	}
+	skin := Skin{
+		Manifest: &structures.SkinManifest{},
+		Sprites:  make(map[string]*structures.SpriteSequence),
+	}
+	if err := json.Unmarshal(dat, skin.Manifest); err != nil {
+		return nil, err
+	}
+
+	atlas := make(map[string]*ebiten.Image)
+	if skin.Manifest.Atlas != "" {
+		if atlas, err = loadSpriteAtlas(assets, path.Join(dir, skin.Manifest.Atlas)); err != nil {
+			return nil, err
+		}
+	}
+
+	known := make(map[string]bool)
+	for _, sprite := range requiredSprites() {
+		known[sprite] = true
+	}
+	for sprite := range wallShapes {
+		known[sprite] = true
+	}
+	for sprite, definition := range skin.Manifest.Sprites {
+		if !known[sprite] {
+			return nil, fmt.Errorf("Skin %q has an unknown sprite %q", name, sprite)
+		}
+		if definition.Pattern != "" {
+			for i := 1; i <= definition.Count; i++ {
+				definition.Frames = append(definition.Frames, fmt.Sprintf(definition.Pattern, i))
+			}
+		}
+		if len(definition.Frames) == 0 {
+			continue
+		}
+		seq, err := loadAnimation(definition, assets, dir, atlas)
+		if err != nil {
+			return nil, fmt.Errorf("Skin %q has an invalid sprite %q: %v", name, sprite, err)
+		}
+		skin.Sprites[sprite] = seq
+	}
+	if skin.Manifest.Font != "" {
+		skin.Manifest.Font = path.Join(dir, skin.Manifest.Font)
+	}
+	for _, hex := range []string{skin.Manifest.Background, skin.Manifest.WallColor} {
+		if hex == "" {
+			continue
+		}
+		if _, err := parseColor(hex); err != nil {
+			return nil, err
+		}
+	}
+	return &skin, nil
+}
+
+// LoadSkin with the name given, falling back to the default skin for the entries it lacks
+func LoadSkin(assets *AssetFS, name string) (*Skin, error) {
+	skin, err := loadSkin(assets, constants.DefaultSkin)
+	if err != nil {
+		return nil, err
+	}
+	for _, sprite := range requiredSprites() {
+		if _, ok := skin.Sprites[sprite]; !ok {
+			return nil, fmt.Errorf("Default skin lacks %q", sprite)
+		}
+	}
+	manifest := skin.Manifest
+	if manifest.Background == "" || manifest.WallColor == "" || manifest.FontSize <= 0 {
+		return nil, fmt.Errorf("Default skin lacks a background color, wall color or font size")
+	}
+	if name == constants.DefaultSkin {
+		return skin, nil
+	}
+
+	custom, err := loadSkin(assets, name)
+	if err != nil {
+		return nil, err
+	}
+	for _, sprite := range requiredSprites() {
+		if seq, ok := custom.Sprites[sprite]; ok {
+			skin.Sprites[sprite] = seq
+		} else {
+			log.Printf("Skin %q lacks %q, using the default one", name, sprite)
+		}
+	}
+	// Wall pieces only make sense along with the wall color, so a skin with either of them
+	// draws the pieces it lacks with its own color instead of taking the default ones
+	customWalls := custom.Manifest.WallColor != ""
+	for sprite := range wallShapes {
+		if _, ok := custom.Sprites[sprite]; ok {
+			customWalls = true
+		}
+	}
+	if customWalls {
+		for sprite := range wallShapes {
+			delete(skin.Sprites, sprite)
+			if seq, ok := custom.Sprites[sprite]; ok {
+				skin.Sprites[sprite] = seq
+			}
+		}
+	}
+	// The font size goes along with the font, since text is laid out for its glyphs
+	if custom.Manifest.Font != "" {
+		manifest.Font = custom.Manifest.Font
+		if custom.Manifest.FontSize > 0 {
+			manifest.FontSize = custom.Manifest.FontSize
+		}
+	}
+	if custom.Manifest.Background != "" {
+		manifest.Background = custom.Manifest.Background
+	}
+	if custom.Manifest.WallColor != "" {
+		manifest.WallColor = custom.Manifest.WallColor
+	}
+	manifest.Name = custom.Manifest.Name
+	return skin, nil
+}
@@ -0,0 +1,118 @@
+package modules
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+// decodeSound file into 16 bit stereo samples, according to its format
+func decodeSound(audioContext *audio.Context, assets *AssetFS, file string) ([]byte, error) {
+	dat, err := assets.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var stream io.Reader
+	src := bytes.NewReader(dat)
+	switch strings.ToLower(path.Ext(file)) {
+	case ".wav":
+		stream, err = wav.Decode(audioContext, src)
+	case ".ogg":
+		stream, err = vorbis.Decode(audioContext, src)
+	case ".mp3":
+		stream, err = mp3.Decode(audioContext, src)
+	default:
+		return nil, fmt.Errorf("Unsupported audio format of %s", file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(stream)
+}
+
+// loadSoundPack with the name given, decoding every sound effect in its manifest
+func loadSoundPack(
+	audioContext *audio.Context,
+	assets *AssetFS,
+	name string,
+) (map[constants.SoundEffect]*structures.SoundSequence, error) {
+	dir := path.Join(constants.SoundPacksDir, name)
+	dat, err := assets.ReadFile(path.Join(dir, constants.SoundPackManifest))
+	if err != nil {
+		return nil, err
+	}
+	var manifest structures.SoundPackManifest
+	if err := json.Unmarshal(dat, &manifest); err != nil {
+		return nil, err
+	}
+
+	effects := make(map[string]constants.SoundEffect)
+	for effect, effectName := range constants.SoundEffectNames {
+		effects[effectName] = effect
+	}
+	sounds := make(map[constants.SoundEffect]*structures.SoundSequence)
+	for effectName, files := range manifest.Sounds {
+		effect, ok := effects[effectName]
+		if !ok {
+			return nil, fmt.Errorf("Sound pack %q has an unknown sound effect %q", name, effectName)
+		}
+		if len(files) == 0 {
+			continue
+		}
+
+		samples := make([][]byte, len(files))
+		for i, file := range files {
+			if samples[i], err = decodeSound(audioContext, assets, path.Join(dir, file)); err != nil {
+				return nil, err
+			}
+		}
+		sounds[effect] = structures.InitSoundSequence(samples)
+	}
+	return sounds, nil
+}
+
+// LoadSoundPack with the name given, falling back to the default pack for the sound effects it lacks
+func LoadSoundPack(
+	audioContext *audio.Context,
+	assets *AssetFS,
+	name string,
+) (map[constants.SoundEffect]*structures.SoundSequence, error) {
+	sounds, err := loadSoundPack(audioContext, assets, constants.DefaultSoundPack)
+	if err != nil {
+		return nil, err
+	}
+	for effect, effectName := range constants.SoundEffectNames {
+		if _, ok := sounds[effect]; !ok {
+			return nil, fmt.Errorf("Default sound pack lacks %q", effectName)
+		}
+	}
+	if name == constants.DefaultSoundPack {
+		return sounds, nil
+	}
+
+	pack, err := loadSoundPack(audioContext, assets, name)
+	if err != nil {
+		return nil, err
+	}
+	for effect, effectName := range constants.SoundEffectNames {
+		if seq, ok := pack[effect]; ok {
+			sounds[effect] = seq
+		} else {
+			log.Printf("Sound pack %q lacks %q, using the default one", name, effectName)
+		}
+	}
+	return sounds, nil
+}
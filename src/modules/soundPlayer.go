@@ -2,120 +2,199 @@ package modules
 
 import (
 	"bytes"
-	"io/ioutil"
+	"context"
+	"sync"
+	"time"
 
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
 	"github.com/hajimehoshi/ebiten/v2/audio"
-	"github.com/hajimehoshi/ebiten/v2/audio/wav"
 )
 
-// InfiniteAudio represents an infinite audio playing on loop
+// Time between the volume changes of a crossfade
+const crossfadeStep = 10 * time.Millisecond
+
+// InfiniteAudio represents an infinite audio playing on a seamless loop
 type InfiniteAudio struct {
-	keepPlaying   bool
-	queued        bool
-	nextSound     constants.SoundEffect
-	currentPlayer *audio.Player
+	mutex       sync.Mutex
+	soundPlayer *EbitenSoundPlayer
+	current     *audio.Player
+	stopped     bool
+	cancelFade  chan struct{}
+	done        chan struct{}
+}
+
+// cancelFading of the previous track, which must be called while holding the mutex
+func (p *InfiniteAudio) cancelFading() {
+	if p.cancelFade != nil {
+		close(p.cancelFade)
+		p.cancelFade = nil
+	}
 }
 
 // Stop infinite audio
 func (p *InfiniteAudio) Stop() {
-	if p.currentPlayer != nil {
-		p.currentPlayer.Pause()
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.stopped {
+		return
 	}
-	p.keepPlaying = false
+	p.stopped = true
+	p.cancelFading()
+	p.soundPlayer.stopPlayer(p.current)
+	close(p.done)
 }
 
-// Replace current audio playing on loop with a different one
-func (p *InfiniteAudio) Replace(effect constants.SoundEffect, instant bool) {
-	if instant && p.currentPlayer != nil {
-		p.currentPlayer.Pause()
+// Replace current audio playing on loop with a different one right away,
+// crossfading both of them if specified
+func (p *InfiniteAudio) Replace(effect constants.SoundEffect, crossfade bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.stopped {
+		return
+	}
+
+	p.cancelFading()
+	previous := p.current
+	gain := 1.0
+	if crossfade {
+		gain = 0
+	}
+	p.current = p.soundPlayer.loopPlayer(effect, gain)
+	p.current.Play()
+	if !crossfade {
+		p.soundPlayer.stopPlayer(previous)
+		return
 	}
-	p.queued = true
-	p.nextSound = effect
-	p.keepPlaying = false
+	p.cancelFade = make(chan struct{})
+	go p.soundPlayer.crossfade(previous, p.current, p.cancelFade)
 }
 
-// SoundPlayer represents the global sound player of the app
-type SoundPlayer struct {
+// EbitenSoundPlayer plays the sounds of the game through the audio device
+type EbitenSoundPlayer struct {
+	Mixer        *Mixer
 	audioContext *audio.Context
+	scheduler    *AudioScheduler
 	sounds       map[constants.SoundEffect]*structures.SoundSequence
 }
 
-func (s *SoundPlayer) playSound(effect constants.SoundEffect) *audio.Player {
+// playSound through the mixer and let the scheduler track when it finishes
+func (s *EbitenSoundPlayer) playSound(effect constants.SoundEffect) *SoundHandle {
 	seq := s.sounds[effect]
 	sound := seq.GetCurrentAudio()
 	audioPlayer := audio.NewPlayerFromBytes(s.audioContext, sound)
 	seq.Advance()
+	s.Mixer.Track(audioPlayer, constants.SoundBuses[effect], 1)
+	if constants.DuckingEffects[effect] {
+		s.Mixer.Duck()
+	}
 	audioPlayer.Play()
-	return audioPlayer
+
+	// Sounds are decoded as 16 bit stereo samples
+	duration := time.Duration(len(sound)/4) * time.Second / constants.SampleRate
+	return s.scheduler.Track(effect, audioPlayer, duration)
+}
+
+// finishSound once the scheduler notices it stopped playing
+func (s *EbitenSoundPlayer) finishSound(handle *SoundHandle) {
+	s.Mixer.Release(handle.player)
+	if constants.DuckingEffects[handle.effect] {
+		s.Mixer.Unduck()
+	}
+}
+
+// loopPlayer of a sound effect that repeats it without any gap
+func (s *EbitenSoundPlayer) loopPlayer(effect constants.SoundEffect, gain float64) *audio.Player {
+	seq := s.sounds[effect]
+	sound := seq.GetCurrentAudio()
+	seq.Advance()
+	loop := audio.NewInfiniteLoop(bytes.NewReader(sound), int64(len(sound)))
+	player, err := audio.NewPlayer(s.audioContext, loop)
+	if err != nil {
+		// Seeking the loop never fails since it is read from memory
+		panic(err)
+	}
+	s.Mixer.Track(player, constants.SoundBuses[effect], gain)
+	return player
+}
+
+// stopPlayer of a loop for good
+func (s *EbitenSoundPlayer) stopPlayer(player *audio.Player) {
+	player.Pause()
+	s.Mixer.Release(player)
+	player.Close()
 }
 
-// PlayOnLoop the specified sound effect
-func (s *SoundPlayer) PlayOnLoop(sound constants.SoundEffect) *InfiniteAudio {
+// crossfade from one loop player to another, stopping the first one once it is silent
+// or right away if the crossfade is cancelled
+func (s *EbitenSoundPlayer) crossfade(from, to *audio.Player, cancel <-chan struct{}) {
+	fromGain, toGain := s.Mixer.Gain(from), s.Mixer.Gain(to)
+	steps := int(constants.CrossfadeDuration * float64(time.Second) / float64(crossfadeStep))
+	ticker := time.NewTicker(crossfadeStep)
+	defer ticker.Stop()
+	for i := 1; i <= steps; i++ {
+		select {
+		case <-cancel:
+			s.stopPlayer(from)
+			return
+		case <-ticker.C:
+			progress := float64(i) / float64(steps)
+			s.Mixer.SetGain(from, fromGain*(1-progress))
+			s.Mixer.SetGain(to, toGain+(1-toGain)*progress)
+		}
+	}
+	s.stopPlayer(from)
+}
+
+// PlayOnLoop the specified sound effect until the audio is stopped or the context is cancelled
+func (s *EbitenSoundPlayer) PlayOnLoop(ctx context.Context, sound constants.SoundEffect) interfaces.LoopingSound {
 	player := &InfiniteAudio{
-		keepPlaying: true,
+		soundPlayer: s,
+		current:     s.loopPlayer(sound, 1),
+		done:        make(chan struct{}),
 	}
-	go func(effect constants.SoundEffect) {
-		wait := make(chan struct{})
-		for {
-			if !player.keepPlaying {
-				if player.queued {
-					player.queued = false
-					player.keepPlaying = true
-					effect = player.nextSound
-				} else {
-					break
-				}
-			}
-			player.currentPlayer = s.PlayOnceAndNotify(effect, wait)
-			<-wait
+	player.current.Play()
+	go func() {
+		select {
+		case <-ctx.Done():
+			player.Stop()
+		case <-player.done:
 		}
-	}(sound)
+	}()
 	return player
 }
 
-// PlayOnceAndNotify when the sound has stopped
-func (s *SoundPlayer) PlayOnceAndNotify(effect constants.SoundEffect, ready chan<- struct{}) *audio.Player {
-	audioPlayer := s.playSound(effect)
-	go func(player *audio.Player) {
-		for player.IsPlaying() {
-		}
-		ready <- struct{}{}
-	}(audioPlayer)
-	return audioPlayer
+// PlayOnceAndNotify through the returned handle when the sound has stopped,
+// stopping it if the context is cancelled first
+func (s *EbitenSoundPlayer) PlayOnceAndNotify(ctx context.Context, effect constants.SoundEffect) interfaces.SoundHandle {
+	handle := s.playSound(effect)
+	go handle.Wait(ctx)
+	return handle
 }
 
 // PlayOnce the specified sound effect once
-func (s *SoundPlayer) PlayOnce(effect constants.SoundEffect) {
+func (s *EbitenSoundPlayer) PlayOnce(effect constants.SoundEffect) {
 	s.playSound(effect)
 }
 
-// InitSoundPlayer with preconfigured sounds from constants
-func InitSoundPlayer() (*SoundPlayer, error) {
-	soundPlayer := SoundPlayer{
-		audioContext: audio.NewContext(44100),
-		sounds:       make(map[constants.SoundEffect]*structures.SoundSequence),
+// InitEbitenSoundPlayer with the sounds of the given pack
+func InitEbitenSoundPlayer(mixer *Mixer, assets *AssetFS, soundPack string) (*EbitenSoundPlayer, error) {
+	// Ebiten allows a single audio context, which is kept when loading another sound pack
+	audioContext := audio.CurrentContext()
+	if audioContext == nil {
+		audioContext = audio.NewContext(constants.SampleRate)
 	}
-	for sound, src := range constants.AudioFiles {
-		files := make([][]byte, len(src))
-		for i, soundFile := range src {
-			dat, err := ioutil.ReadFile(soundFile)
-			if err != nil {
-				return nil, err
-			}
-			s, err := wav.Decode(soundPlayer.audioContext, bytes.NewReader(dat))
-			if err != nil {
-				return nil, err
-			}
-			b, err := ioutil.ReadAll(s)
-			if err != nil {
-				return nil, err
-			}
-			files[i] = b
-		}
-		soundPlayer.sounds[sound] = structures.InitSoundSequence(files)
+	sounds, err := LoadSoundPack(audioContext, assets, soundPack)
+	if err != nil {
+		return nil, err
 	}
 
+	soundPlayer := EbitenSoundPlayer{
+		Mixer:        mixer,
+		audioContext: audioContext,
+		sounds:       sounds,
+	}
+	soundPlayer.scheduler = InitAudioScheduler(soundPlayer.finishSound)
 	return &soundPlayer, nil
 }
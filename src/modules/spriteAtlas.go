@@ -0,0 +1,38 @@
+package modules
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"path"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// loadSpriteAtlas with the frames of its image by name
+func loadSpriteAtlas(assets *AssetFS, file string) (map[string]*ebiten.Image, error) {
+	dat, err := assets.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var atlas structures.SpriteAtlas
+	if err := json.Unmarshal(dat, &atlas); err != nil {
+		return nil, err
+	}
+
+	img, err := assets.LoadImage(path.Join(path.Dir(file), atlas.Image))
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	frames := make(map[string]*ebiten.Image)
+	for name, rect := range atlas.Frames {
+		frame := image.Rect(rect.X, rect.Y, rect.X+rect.W, rect.Y+rect.H)
+		if frame.Empty() || !frame.In(bounds) {
+			return nil, fmt.Errorf("Frame %q is out of the bounds of atlas %s", name, file)
+		}
+		frames[name] = img.SubImage(frame).(*ebiten.Image)
+	}
+	return frames, nil
+}
@@ -0,0 +1,186 @@
+package modules
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+// Thickness of the outlines of the walls in pixels
+const wallThickness = 4
+
+// Directions a wall piece connects to, combined as a mask to index the pieces
+const (
+	wallUp = 1 << iota
+	wallRight
+	wallDown
+	wallLeft
+)
+
+// Connections of the wall pieces a skin can define, drawn connecting upwards first since the
+// rest of the masks are obtained by rotating them clockwise
+var wallShapes = map[string]int{
+	"wall-end":      wallUp,
+	"wall-straight": wallUp | wallDown,
+	"wall-corner":   wallUp | wallRight,
+	"wall-tee":      wallUp | wallRight | wallDown,
+	"wall-cross":    wallUp | wallRight | wallDown | wallLeft,
+}
+
+// rotateWallMask a quarter turn clockwise
+func rotateWallMask(mask int) int {
+	return (mask<<1 | mask>>3) & 0xf
+}
+
+// renderWallPiece from a sprite of any size, rotated the given quarter turns clockwise
+func renderWallPiece(sprite *ebiten.Image, turns int) *ebiten.Image {
+	piece := ebiten.NewImage(constants.TileSize, constants.TileSize)
+	width, height := sprite.Size()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(constants.TileSize/float64(width), constants.TileSize/float64(height))
+	op.GeoM.Translate(-constants.TileSize/2, -constants.TileSize/2)
+	op.GeoM.Rotate(float64(turns) * math.Pi / 2)
+	op.GeoM.Translate(constants.TileSize/2, constants.TileSize/2)
+	piece.DrawImage(sprite, op)
+	return piece
+}
+
+// loadWallPieces from the sprites of a skin: its wall sprite for isolated walls plus every
+// rotation of the shapes it defines. The pieces it lacks are rendered with its wall color
+func loadWallPieces(sprites map[string]*structures.SpriteSequence, wallColor color.Color) []*ebiten.Image {
+	pieces := renderWallPieces(wallColor)
+	if sprite, ok := sprites["wall"]; ok {
+		pieces[0] = renderWallPiece(sprite.GetCurrentFrame(), 0)
+	}
+	for name, shape := range wallShapes {
+		sprite, ok := sprites[name]
+		if !ok {
+			continue
+		}
+		mask := shape
+		for turns := 0; turns < 4 && (turns == 0 || mask != shape); turns++ {
+			pieces[mask] = renderWallPiece(sprite.GetCurrentFrame(), turns)
+			mask = rotateWallMask(mask)
+		}
+	}
+	return pieces
+}
+
+// renderWallPieces for every mask of connected directions: ends, straight segments,
+// corners, T-junctions and crossings, plus a single dot for isolated walls
+func renderWallPieces(wallColor color.Color) []*ebiten.Image {
+	const center = (constants.TileSize - wallThickness) / 2
+	pieces := make([]*ebiten.Image, 16)
+	for mask := range pieces {
+		piece := ebiten.NewImage(constants.TileSize, constants.TileSize)
+		ebitenutil.DrawRect(piece, center, center, wallThickness, wallThickness, wallColor)
+		if mask&wallUp != 0 {
+			ebitenutil.DrawRect(piece, center, 0, wallThickness, center, wallColor)
+		}
+		if mask&wallRight != 0 {
+			ebitenutil.DrawRect(piece, center, center, constants.TileSize-center, wallThickness, wallColor)
+		}
+		if mask&wallDown != 0 {
+			ebitenutil.DrawRect(piece, center, center, wallThickness, constants.TileSize-center, wallColor)
+		}
+		if mask&wallLeft != 0 {
+			ebitenutil.DrawRect(piece, 0, center, center, wallThickness, wallColor)
+		}
+		pieces[mask] = piece
+	}
+	return pieces
+}
+
+// WallTiler picks the piece of every wall of a maze according to its neighbouring walls
+type WallTiler struct {
+	cols   int
+	rows   int
+	walls  [][]bool
+	pieces []*ebiten.Image
+}
+
+func (t *WallTiler) isWall(x, y int) bool {
+	return x >= 0 && x < t.cols && y >= 0 && y < t.rows && t.walls[y][x]
+}
+
+// connects two neighbouring walls unless the walls on both sides of them fill the space,
+// so only the outline of thick blocks is drawn
+func (t *WallTiler) connects(x, y, dx, dy int) bool {
+	if !t.isWall(x+dx, y+dy) {
+		return false
+	}
+	// Neighbours on each side of the connection, perpendicular to it
+	sx, sy := dy, dx
+	return !(t.isWall(x+sx, y+sy) && t.isWall(x+dx+sx, y+dy+sy) &&
+		t.isWall(x-sx, y-sy) && t.isWall(x+dx-sx, y+dy-sy))
+}
+
+// Piece of the wall at the given position, being nil for walls surrounded by more walls
+func (t *WallTiler) Piece(x, y int) *ebiten.Image {
+	mask := 0
+	for _, neighbour := range []struct{ dx, dy, bit int }{
+		{0, -1, wallUp},
+		{1, 0, wallRight},
+		{0, 1, wallDown},
+		{-1, 0, wallLeft},
+	} {
+		if t.connects(x, y, neighbour.dx, neighbour.dy) {
+			mask |= neighbour.bit
+		}
+	}
+	if mask == 0 {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if !t.isWall(x+dx, y+dy) {
+					return t.pieces[mask]
+				}
+			}
+		}
+		return nil
+	}
+	return t.pieces[mask]
+}
+
+// Render every wall of the maze into a single background image
+func (t *WallTiler) Render() *ebiten.Image {
+	background := ebiten.NewImage(t.cols*constants.TileSize, t.rows*constants.TileSize)
+	for y := 0; y < t.rows; y++ {
+		for x := 0; x < t.cols; x++ {
+			if !t.walls[y][x] {
+				continue
+			}
+			if piece := t.Piece(x, y); piece != nil {
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Translate(float64(x*constants.TileSize), float64(y*constants.TileSize))
+				background.DrawImage(piece, op)
+			}
+		}
+	}
+	return background
+}
+
+// InitWallTiler for the walls of a maze, drawing them with the given pieces
+func InitWallTiler(maze *structures.Maze, pieces []*ebiten.Image) *WallTiler {
+	cols, rows := maze.Dimensions()
+	tiler := WallTiler{
+		cols:   cols,
+		rows:   rows,
+		walls:  make([][]bool, rows),
+		pieces: pieces,
+	}
+	for y := range tiler.walls {
+		tiler.walls[y] = make([]bool, cols)
+		for x := range tiler.walls[y] {
+			for _, object := range maze.ElementsAt(x, y) {
+				if object.GetLayerIndex() == constants.WallLayerIdx {
+					tiler.walls[y][x] = true
+				}
+			}
+		}
+	}
+	return &tiler
+}
@@ -0,0 +1,268 @@
+package screens
+
+import (
+	"image/color"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// editorTool that paints a tile of the level format
+type editorTool struct {
+	name string
+	tile rune
+}
+
+var editorTools = []editorTool{
+	{name: "WALL", tile: '#'},
+	{name: "BARS", tile: '|'},
+	{name: "PELLET", tile: '.'},
+	{name: "POWER", tile: '@'},
+	{name: "START", tile: 'S'},
+	{name: "HOME", tile: 'G'},
+	{name: "BLINKY", tile: 'B'},
+	{name: "PINKY", tile: 'P'},
+	{name: "INKY", tile: 'I'},
+	{name: "CLYDE", tile: 'C'},
+	{name: "TUNNEL", tile: 'T'},
+	{name: "NO-TURN", tile: 't'},
+	{name: "RED ZONE", tile: 'R'},
+	{name: "RED EMPTY", tile: 'r'},
+	{name: "ERASE", tile: ' '},
+}
+
+// editorButton in the bottom bar of the editor
+type editorButton struct {
+	label  string
+	x      int
+	action func()
+}
+
+// Editor represents the level editor screen
+type Editor struct {
+	w           int
+	h           int
+	anchorCtx   *contexts.AnchorContext
+	keepRunning bool
+	mutex       sync.Mutex
+	grid        [][]rune
+	tool        int
+	status      string
+	buttons     []editorButton
+}
+
+func (e *Editor) lines() []string {
+	lines := make([]string, len(e.grid))
+	for i, row := range e.grid {
+		lines[i] = string(row)
+	}
+	return lines
+}
+
+// load the edited level, or the default one if nothing has been saved yet
+func (e *Editor) load() {
+	lines, err := modules.LoadMaze(e.anchorCtx.Assets, e.anchorCtx.EditorFile)
+	if err != nil {
+		lines, err = modules.LoadMaze(e.anchorCtx.Assets, constants.DefaultLevelFile)
+	}
+	if err != nil {
+		e.status = err.Error()
+		return
+	}
+
+	for y, row := range e.grid {
+		for x := range row {
+			row[x] = ' '
+			if y < len(lines) && x < len(lines[y]) {
+				row[x] = rune(lines[y][x])
+			}
+		}
+	}
+	e.status = "LOADED"
+}
+
+// save the edited level once it is valid, indicating whether it was saved
+func (e *Editor) save() bool {
+	lines := e.lines()
+	if err := modules.ValidateLevel(lines); err != nil {
+		e.status = err.Error()
+		return false
+	}
+	if err := modules.SaveMaze(lines, e.anchorCtx.EditorFile); err != nil {
+		e.status = err.Error()
+		return false
+	}
+	e.status = "SAVED"
+	return true
+}
+
+// playTest the edited level, which is saved first
+func (e *Editor) playTest() {
+	if !e.save() {
+		return
+	}
+	e.keepRunning = false
+	e.anchorCtx.PlayTest = true
+}
+
+// paint a tile, moving it if only one of its kind is allowed in the level
+func (e *Editor) paint(x, y int, tile rune) {
+	for _, unique := range []rune{'S', 'G', 'B', 'P', 'I', 'C'} {
+		if tile != unique {
+			continue
+		}
+		for _, row := range e.grid {
+			for i := range row {
+				if row[i] == unique {
+					row[i] = ' '
+				}
+			}
+		}
+	}
+	e.grid[y][x] = tile
+}
+
+// click in the given position of the screen, painting the maze or pressing a button
+func (e *Editor) click(x, y int, erase bool) {
+	col, row := x/constants.TileSize, y/constants.TileSize
+	if col >= 0 && col < constants.HorizontalTiles && row >= 0 && row < constants.VerticalTiles {
+		tile := editorTools[e.tool].tile
+		if erase {
+			tile = ' '
+		}
+		e.paint(col, row, tile)
+		return
+	}
+
+	for _, button := range e.buttons {
+		if x >= button.x && x < button.x+textWidth(e.anchorCtx.FontFace, button.label) && row == constants.VerticalTiles {
+			button.action()
+		}
+	}
+}
+
+// Run editor input listener
+func (e *Editor) Run() {
+	wasPressed := false
+	wasClicked := false
+	for e.keepRunning {
+		x, y := ebiten.CursorPosition()
+		leftClicked := ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+		rightClicked := ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight)
+		tabPressed := ebiten.IsKeyPressed(ebiten.KeyTab)
+
+		e.mutex.Lock()
+		if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+			e.keepRunning = false
+		} else if tabPressed && !wasPressed {
+			e.tool = utils.Mod(e.tool+1, len(editorTools))
+		}
+		for i := 0; i < len(editorTools) && i < 10; i++ {
+			if ebiten.IsKeyPressed(ebiten.Key0 + ebiten.Key(utils.Mod(i+1, 10))) {
+				e.tool = i
+			}
+		}
+		// Paint while dragging, but only press buttons once per click
+		if leftClicked || rightClicked {
+			if y < constants.VerticalTiles*constants.TileSize || !wasClicked {
+				e.click(x, y, rightClicked)
+			}
+		}
+		e.mutex.Unlock()
+
+		wasPressed = tabPressed
+		wasClicked = leftClicked || rightClicked
+		time.Sleep(time.Duration(10) * time.Millisecond)
+	}
+
+	if e.anchorCtx.PlayTest {
+		e.anchorCtx.ChangeState <- constants.PlayState
+	} else {
+		e.anchorCtx.ChangeState <- constants.MenuState
+	}
+}
+
+func (e *Editor) drawTile(screen *ebiten.Image, x, y int, tile rune) {
+	sprite := e.anchorCtx.AssetManager.TileSprite(tile)
+	if sprite != nil {
+		op := &ebiten.DrawImageOptions{}
+		width, height := sprite.Size()
+		op.GeoM.Scale(constants.TileSize/float64(width), constants.TileSize/float64(height))
+		op.GeoM.Translate(float64(x*constants.TileSize), float64(y*constants.TileSize))
+		screen.DrawImage(sprite, op)
+	}
+	// Tiles without a sprite of their own are labeled with their character
+	if strings.ContainsRune("BPICGTtRr", tile) {
+		text.Draw(screen, string(tile), e.anchorCtx.FontFace, x*constants.TileSize+1, (y+1)*constants.TileSize-1, color.White)
+	}
+}
+
+// Draw the editor screen
+func (e *Editor) Draw(screen *ebiten.Image) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for y, row := range e.grid {
+		for x, tile := range row {
+			e.drawTile(screen, x, y, tile)
+		}
+	}
+
+	x, y := ebiten.CursorPosition()
+	col, row := x/constants.TileSize, y/constants.TileSize
+	if col >= 0 && col < constants.HorizontalTiles && row >= 0 && row < constants.VerticalTiles {
+		ebitenutil.DrawRect(
+			screen,
+			float64(col*constants.TileSize),
+			float64(row*constants.TileSize),
+			constants.TileSize,
+			constants.TileSize,
+			color.RGBA{255, 255, 255, 80},
+		)
+	}
+
+	barY := constants.VerticalTiles * constants.TileSize
+	tool := editorTools[e.tool]
+	e.drawTile(screen, 0, constants.VerticalTiles, tool.tile)
+	text.Draw(screen, tool.name, e.anchorCtx.FontFace, constants.TileSize+10, barY+30, color.White)
+	for _, button := range e.buttons {
+		text.Draw(screen, button.label, e.anchorCtx.FontFace, button.x, barY+30, color.RGBA{255, 255, 0, 255})
+	}
+	status := strings.ToUpper(e.status)
+	for len(status) > 0 && textWidth(e.anchorCtx.FontFace, status) > e.w-20 {
+		status = status[:len(status)-1]
+	}
+	text.Draw(screen, status, e.anchorCtx.FontFace, 10, barY+80, color.White)
+}
+
+// NewEditor screen with the last edited level
+func NewEditor(w, h int, anchorCtx *contexts.AnchorContext) *Editor {
+	editor := &Editor{
+		w:           w,
+		h:           h,
+		anchorCtx:   anchorCtx,
+		keepRunning: true,
+		grid:        make([][]rune, constants.VerticalTiles),
+	}
+	for y := range editor.grid {
+		editor.grid[y] = make([]rune, constants.HorizontalTiles)
+	}
+
+	labels := []string{"LOAD", "SAVE", "PLAY"}
+	actions := []func(){editor.load, func() { editor.save() }, editor.playTest}
+	x := w - 10
+	for i := len(labels) - 1; i >= 0; i-- {
+		x -= textWidth(anchorCtx.FontFace, labels[i]) + 20
+		editor.buttons = append(editor.buttons, editorButton{label: labels[i], x: x, action: actions[i]})
+	}
+	editor.load()
+	return editor
+}
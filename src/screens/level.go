@@ -2,9 +2,15 @@ package screens
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"image/color"
-	"os"
+	"io"
+	"log"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
@@ -12,29 +18,132 @@ import (
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/models"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/utils"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text"
 )
 
+var sirenSounds = []constants.SoundEffect{
+	constants.GhostSirenPhase1,
+	constants.GhostSirenPhase2,
+	constants.GhostSirenPhase3,
+	constants.GhostSirenPhase4,
+}
+
+var allGhosts = []constants.GhostType{
+	constants.Blinky,
+	constants.Pinky,
+	constants.Inky,
+	constants.Clyde,
+}
+
+var bobDirections = []constants.Direction{constants.DirLeft, constants.DirRight}
+
+// pelletSpot where a pellet was placed when parsing the level
+type pelletSpot struct {
+	x          int
+	y          int
+	isPowerful bool
+}
+
 // Level represents a level with all of its contents
 type Level struct {
+	number           int
+	numEnemies       int
+	lives            int
 	pelletsRemaining uint
-	phase            int
+	sirenPhase       int
+	isRoundActive    bool
+	respawning       bool
+	timeLeft         float64
+	survivedTime     float64
+	nextWaveIn       float64
+	nextPelletsIn    float64
 	anchorCtx        *contexts.AnchorContext
 	ctx              *contexts.GameContext
+	playerStart      interfaces.Location
+	pelletSpots      []pelletSpot
 	player           *models.Pacman
 	enemies          []*models.Ghost
-	backgroundSound  *modules.InfiniteAudio
+	house            *models.GhostHouse
+	backgroundSound  interfaces.LoopingSound
+	stopRunning      context.CancelFunc
+	generator        *modules.MazeGenerator
+	renderer         *modules.MazeRenderer
 }
 
-func (l *Level) parseLevel(file string, numEnemies int) error {
-	f, err := os.Open(file)
+func (l *Level) spawnPlayer() {
+	x, y := l.playerStart.X(), l.playerStart.Y()
+	player := models.InitPacman(x, y, l.ctx.Settings.Speeds.Pacman, l.anchorCtx.AssetManager)
+	player.AttachCollisionDetector(modules.InitCollisionDetector(player, l.ctx.Maze))
+	if l.player != nil {
+		player.Score = l.player.Score
+	}
+	l.ctx.MainPlayer = player
+	l.player = player
+	l.ctx.Maze.AddElement(y, x, player)
+}
+
+// newGhost at home, being the i-th one of the level
+func (l *Level) newGhost(i int) (*models.Ghost, error) {
+	ghostType := allGhosts[i%len(allGhosts)]
+	ghost, err := models.InitGhost(
+		l.ctx.GhostHome.X(),
+		l.ctx.GhostHome.Y(),
+		l.ctx.Settings.Speeds.Ghost,
+		bobDirections[i%len(bobDirections)],
+		ghostType,
+		l.anchorCtx.AssetManager.GhostSprites(ghostType),
+		l.ctx,
+	)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	ghost.AttachCollisionDetector(modules.InitCollisionDetector(ghost, l.ctx.Maze))
+	return ghost, nil
+}
 
-	defer f.Close()
+func (l *Level) spawnGhosts() error {
+	x, y := l.ctx.GhostHome.X(), l.ctx.GhostHome.Y()
+	l.enemies = make([]*models.Ghost, 0, l.numEnemies)
+	for i := 0; i < l.numEnemies; i++ {
+		ghost, err := l.newGhost(i)
+		if err != nil {
+			return err
+		}
+		l.enemies = append(l.enemies, ghost)
+	}
+	// Add to maze in reverse order so that red ghost will always be painted first
+	for i := len(l.enemies) - 1; i >= 0; i-- {
+		l.ctx.Maze.AddElement(y, x, l.enemies[i])
+	}
+	l.house = models.InitGhostHouse(l.enemies, l.ctx)
+	return nil
+}
+
+// locateGhostExit as the tile right after the bars closest to the ghost home
+func (l *Level) locateGhostExit(bars []interfaces.Location) error {
+	if l.ctx.GhostHome == nil || len(bars) == 0 {
+		return errors.New("Level must have a ghost home and bars to exit from it")
+	}
+
+	door := bars[0]
+	for _, candidate := range bars {
+		if candidate.DistanceTo(l.ctx.GhostHome) < door.DistanceTo(l.ctx.GhostHome) {
+			door = candidate
+		}
+	}
+	dx := door.X() - l.ctx.GhostHome.X()
+	dy := door.Y() - l.ctx.GhostHome.Y()
+	step := constants.Direction{X: utils.Sign(dx), Y: 0}
+	if utils.Abs(dy) >= utils.Abs(dx) {
+		step = constants.Direction{X: 0, Y: utils.Sign(dy)}
+	}
+	l.ctx.GhostExit = structures.InitPosition(door.X()+step.X, door.Y()+step.Y)
+	return nil
+}
 
+func (l *Level) parseLevel(levelInput io.Reader) error {
 	ghostBases := map[rune]constants.GhostType{
 		'B': constants.Blinky,
 		'P': constants.Pinky,
@@ -42,7 +151,8 @@ func (l *Level) parseLevel(file string, numEnemies int) error {
 		'C': constants.Clyde,
 	}
 	l.ctx.Maze = structures.InitMaze()
-	input := bufio.NewScanner(f)
+	bars := make([]interfaces.Location, 0)
+	input := bufio.NewScanner(levelInput)
 	for row := 0; input.Scan(); row++ {
 		line := input.Text()
 		l.ctx.Maze.AddRow((len(line)))
@@ -56,41 +166,31 @@ func (l *Level) parseLevel(file string, numEnemies int) error {
 				wall := models.InitWall(col, row, l.anchorCtx.AssetManager)
 				l.ctx.Maze.AddElement(row, col, wall)
 			case '|':
-				bars := models.InitBars(col, row, l.anchorCtx.AssetManager)
-				l.ctx.Maze.AddElement(row, col, bars)
+				bars = append(bars, structures.InitPosition(col, row))
+				l.ctx.Maze.AddElement(row, col, models.InitBars(col, row, l.anchorCtx.AssetManager))
 			case 'S':
-				player := models.InitPacman(col, row, l.anchorCtx.AssetManager)
-				player.AttachCollisionDetector(modules.InitCollisionDetector(player, l.ctx.Maze))
-				l.ctx.MainPlayer = player
-				l.player = player
-				l.ctx.Maze.AddElement(row, col, player)
+				l.playerStart = structures.InitPosition(col, row)
+				l.spawnPlayer()
 			case 'G':
-				allGhosts := []constants.GhostType{
-					constants.Blinky,
-					constants.Pinky,
-					constants.Inky,
-					constants.Clyde,
-				}
-				for i := 0; i < numEnemies; i++ {
-					ghost, err := models.InitGhost(
-						col,
-						row,
-						float64(i)*constants.TimeBetweenSpawns,
-						allGhosts[i%len(allGhosts)],
-					)
-					if err != nil {
-						return err
-					}
-					ghost.AttachCollisionDetector(modules.InitCollisionDetector(ghost, l.ctx.Maze))
-					l.enemies = append(l.enemies, ghost)
-				}
 				l.ctx.GhostHome = structures.InitPosition(col, row)
-				// Add to maze in reverse order so that red ghost will always be painted first
-				for i := len(l.enemies) - 1; i >= 0; i-- {
-					l.ctx.Maze.AddElement(row, col, l.enemies[i])
+				if err := l.spawnGhosts(); err != nil {
+					return err
+				}
+			case 'T', 't':
+				tunnel := models.InitTunnel(col, row, elem == 'T')
+				l.ctx.Maze.AddElement(row, col, tunnel)
+			case 'R', 'r':
+				redZone := models.InitRedZone(col, row)
+				l.ctx.Maze.AddElement(row, col, redZone)
+				if elem == 'R' {
+					l.pelletsRemaining++
+					l.pelletSpots = append(l.pelletSpots, pelletSpot{x: col, y: row, isPowerful: false})
+					pellet := models.InitPellet(col, row, false, l.anchorCtx.AssetManager)
+					l.ctx.Maze.AddElement(row, col, pellet)
 				}
 			case '.', '@':
 				l.pelletsRemaining++
+				l.pelletSpots = append(l.pelletSpots, pelletSpot{x: col, y: row, isPowerful: elem == '@'})
 				pellet := models.InitPellet(col, row, elem == '@', l.anchorCtx.AssetManager)
 				l.ctx.Maze.AddElement(row, col, pellet)
 			}
@@ -101,56 +201,296 @@ func (l *Level) parseLevel(file string, numEnemies int) error {
 		return err
 	}
 
-	return nil
+	l.renderer = modules.InitMazeRenderer(l.ctx.Maze, l.anchorCtx.AssetManager.WallPieces)
+	return l.locateGhostExit(bars)
 }
 
-// Run logic of the level
-func (l *Level) Run() {
-	wait := make(chan struct{})
-	l.ctx.SoundPlayer.PlayOnceAndNotify(constants.GameStart, wait)
-	<-wait
-	sirenSounds := []constants.SoundEffect{
-		constants.GhostSirenPhase1,
-		constants.GhostSirenPhase2,
-		constants.GhostSirenPhase3,
-		constants.GhostSirenPhase4,
+func (l *Level) broadcastPelletsRemaining() {
+	for _, enemy := range l.enemies {
+		enemy.UpdatePelletsRemaining(l.pelletsRemaining)
 	}
+}
 
-	l.backgroundSound = l.ctx.SoundPlayer.PlayOnLoop(sirenSounds[l.phase])
+func (l *Level) startRound() {
+	l.isRoundActive = true
+	l.broadcastPelletsRemaining()
+	l.sirenPhase = 0
+	l.ctx.Modes.Reset()
+	l.backgroundSound = l.ctx.SoundPlayer.PlayOnLoop(l.ctx.Running, sirenSounds[l.sirenPhase])
 	go l.player.Run(l.ctx)
 	for _, enemy := range l.enemies {
 		go enemy.Run(l.ctx)
 	}
+}
+
+// respawn PacMan and the ghosts in their initial positions after losing a life
+func (l *Level) respawn() error {
+	l.ctx.MazeMutex.Lock()
+	defer l.ctx.MazeMutex.Unlock()
+	l.spawnPlayer()
+	if err := l.spawnGhosts(); err != nil {
+		return err
+	}
+	l.house.LifeLost()
+	return nil
+}
+
+// switchMode of every ghost at once, advancing the siren whenever a chase phase ends
+func (l *Level) switchMode() {
+	mode := l.ctx.Modes.Mode()
+	for _, enemy := range l.enemies {
+		enemy.ChangeState(mode)
+	}
+
+	sirenPhase := utils.Min(l.ctx.Modes.Phase()/2, len(sirenSounds)-1)
+	if sirenPhase != l.sirenPhase {
+		l.sirenPhase = sirenPhase
+		l.backgroundSound.Replace(sirenSounds[l.sirenPhase], true)
+	}
+}
+
+// nextMaze of the endless mode, with one more ghost and the settings of the next level
+func (l *Level) nextMaze() error {
+	lines, err := l.generator.Generate()
+	if err != nil {
+		return err
+	}
+
+	l.ctx.MazeMutex.Lock()
+	defer l.ctx.MazeMutex.Unlock()
+	l.number++
+	l.numEnemies = utils.Min(l.numEnemies+1, constants.MaxGhostsAllowed)
+	l.pelletsRemaining = 0
+	l.ctx.Settings = l.ctx.Difficulty.ForLevel(l.number)
+	l.ctx.Modes = modules.InitModeScheduler(l.ctx.Settings.ModeDurations)
+	log.Printf("Loading maze %d with %d enemies", l.number, l.numEnemies)
+	return l.parseLevel(strings.NewReader(strings.Join(lines, "\n")))
+}
+
+// spawnWave of the survival mode, sending one more ghost into the house. The maze is updated
+// from another goroutine since PacMan may be holding it while notifying the level
+func (l *Level) spawnWave() error {
+	if l.numEnemies >= constants.MaxSurvivalGhosts {
+		return nil
+	}
+
+	ghost, err := l.newGhost(l.numEnemies)
+	if err != nil {
+		return err
+	}
+	l.numEnemies++
+	l.enemies = append(l.enemies, ghost)
+	l.house.Admit(ghost)
+	go func(ghost *models.Ghost) {
+		l.ctx.MazeMutex.Lock()
+		l.ctx.Maze.AddElement(l.ctx.GhostHome.Y(), l.ctx.GhostHome.X(), ghost)
+		l.ctx.MazeMutex.Unlock()
+		ghost.Run(l.ctx)
+	}(ghost)
+	return nil
+}
+
+// respawnPellets that were eaten in the survival mode. Like waves, they are placed from
+// another goroutine, which reports how many pellets were respawned before releasing the maze so
+// the count is always waiting for the level by the time PacMan can eat any of them
+func (l *Level) respawnPellets() {
+	// A single respawn is pending at a time, so reporting it never blocks
+	if l.respawning {
+		return
+	}
+	l.respawning = true
+	go func(spots []pelletSpot) {
+		var respawned uint
+		l.ctx.MazeMutex.Lock()
+		defer l.ctx.MazeMutex.Unlock()
+		for _, spot := range spots {
+			hasPellet := false
+			for _, elem := range l.ctx.Maze.ElementsAt(spot.x, spot.y) {
+				if _, ok := elem.(*models.Pellet); ok {
+					hasPellet = true
+				}
+			}
+			if !hasPellet {
+				pellet := models.InitPellet(spot.x, spot.y, spot.isPowerful, l.anchorCtx.AssetManager)
+				l.ctx.Maze.AddElement(spot.y, spot.x, pellet)
+				respawned++
+			}
+		}
+		l.ctx.Msg.PelletsRespawned <- respawned
+	}(l.pelletSpots)
+}
+
+// addRespawnedPellets to the ones remaining once their respawn is reported
+func (l *Level) addRespawnedPellets(respawned uint) {
+	l.respawning = false
+	l.pelletsRemaining += respawned
+	l.broadcastPelletsRemaining()
+}
+
+// collectRespawnedPellets that were reported but not received yet, which must be done before
+// counting an eaten pellet since it may be one of them
+func (l *Level) collectRespawnedPellets() {
+	select {
+	case respawned := <-l.ctx.Msg.PelletsRespawned:
+		l.addRespawnedPellets(respawned)
+	default:
+	}
+}
+
+// survive for some more time, scoring every whole second and escalating the waves of ghosts
+func (l *Level) survive(elapsed float64) error {
+	if l.anchorCtx.Mode != constants.SurvivalMode || !l.isRoundActive {
+		return nil
+	}
+
+	seconds := uint(l.survivedTime)
+	l.survivedTime += elapsed
+	l.player.AddScore((uint(l.survivedTime) - seconds) * constants.SurvivalPointsPerSecond)
+
+	l.nextPelletsIn -= elapsed
+	if l.nextPelletsIn <= 0 {
+		l.nextPelletsIn = constants.PelletRespawnInterval
+		l.respawnPellets()
+	}
+	l.nextWaveIn -= elapsed
+	if l.nextWaveIn <= 0 {
+		l.nextWaveIn = constants.SurvivalWaveInterval
+		return l.spawnWave()
+	}
+	return nil
+}
+
+// clock shown in the HUD of the modes that are played against time
+func (l *Level) clock() (float64, bool) {
+	switch l.anchorCtx.Mode {
+	case constants.TimeAttackMode:
+		return l.timeLeft, true
+	case constants.SurvivalMode:
+		return l.survivedTime, true
+	default:
+		return 0, false
+	}
+}
+
+// outOfTime when the countdown of the time attack has run out
+func (l *Level) outOfTime() bool {
+	return l.anchorCtx.Mode == constants.TimeAttackMode && l.timeLeft <= 0
+}
+
+// countdown the time left of the time attack, ending the game once it runs out
+func (l *Level) countdown(elapsed float64) {
+	if l.anchorCtx.Mode != constants.TimeAttackMode || l.outOfTime() {
+		return
+	}
+	l.timeLeft -= elapsed
+	if l.outOfTime() {
+		l.timeLeft = 0
+		l.player.ChangeState(constants.TimeUp)
+	}
+}
+
+// addTime to the countdown of the time attack
+func (l *Level) addTime(seconds float64) {
+	if l.anchorCtx.Mode == constants.TimeAttackMode && !l.outOfTime() {
+		l.timeLeft += seconds
+	}
+}
+
+func (l *Level) finish() {
+	// Stop every sound of the level that may still be playing
+	l.stopRunning()
+
+	// Play tests go back to the editor without recording any score
+	if l.anchorCtx.PlayTest {
+		l.anchorCtx.PlayTest = false
+		l.anchorCtx.ChangeState <- constants.EditorState
+		return
+	}
+
+	l.anchorCtx.GameScore = l.player.Score
+	if _, err := l.anchorCtx.ScoreBoard.Record(l.anchorCtx.Mode, l.player.Score); err != nil {
+		log.Println(err)
+	}
+	l.anchorCtx.ChangeState <- constants.GameOverState
+}
+
+// Run logic of the level
+func (l *Level) Run() {
+	<-l.ctx.SoundPlayer.PlayOnceAndNotify(l.ctx.Running, constants.GameStart).Done()
+
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	lastTick := time.Now()
+	l.startRound()
 MainLoop:
 	for {
 		select {
-		case newPhase := <-l.ctx.Msg.PhaseChange:
-			if newPhase > l.phase {
-				l.backgroundSound.Replace(sirenSounds[newPhase%len(sirenSounds)], false)
-				l.phase = newPhase
+		case now := <-ticker.C:
+			elapsed := now.Sub(lastTick).Seconds()
+			l.house.Update()
+			l.ctx.Director.Evaluate()
+			l.countdown(elapsed)
+			if err := l.survive(elapsed); err != nil {
+				log.Fatal(err)
+			}
+			if l.ctx.Modes.Advance(elapsed) {
+				l.switchMode()
 			}
+			lastTick = now
 		case isPowerful := <-l.ctx.Msg.EatPellet:
+			l.collectRespawnedPellets()
 			l.pelletsRemaining--
-			if l.pelletsRemaining == 0 {
+			l.broadcastPelletsRemaining()
+			l.house.PelletEaten()
+			l.ctx.Director.PelletEaten()
+			if l.pelletsRemaining == 0 && l.anchorCtx.Mode == constants.SurvivalMode {
+				l.nextPelletsIn = constants.PelletRespawnInterval
+				l.respawnPellets()
+			} else if l.pelletsRemaining == 0 {
 				l.player.ChangeState(constants.AllPelletsEaten)
 				break
 			}
 			if isPowerful {
+				l.addTime(constants.PowerPelletTimeBonus)
+				l.ctx.Modes.Pause()
 				l.backgroundSound.Replace(constants.PowerPellet, true)
 				for _, enemy := range l.enemies {
 					enemy.ChangeState(constants.PowerPelletEaten)
 				}
 			}
+		case respawned := <-l.ctx.Msg.PelletsRespawned:
+			l.addRespawnedPellets(respawned)
+		case <-l.ctx.Msg.EatGhost:
+			l.addTime(constants.GhostEatenTimeBonus)
 		case <-l.ctx.Msg.PowerPelletWoreOff:
-			l.backgroundSound.Replace(sirenSounds[l.phase%len(sirenSounds)], true)
+			l.ctx.Modes.Resume()
+			l.backgroundSound.Replace(sirenSounds[l.sirenPhase], true)
 		case <-l.ctx.Msg.RemoveEnemies:
+			l.isRoundActive = false
 			l.backgroundSound.Stop()
 			for _, enemy := range l.enemies {
 				enemy.ChangeState(constants.GameOver)
 			}
+		case <-l.ctx.Msg.PacmanDied:
+			l.lives--
+			l.ctx.Director.LifeLost()
+			if l.lives == 0 || l.outOfTime() {
+				l.finish()
+				break MainLoop
+			}
+			if err := l.respawn(); err != nil {
+				log.Fatal(err)
+			}
+			l.startRound()
 		case <-l.ctx.Msg.EndGame:
-			l.anchorCtx.GameScore = l.player.Score
-			l.anchorCtx.ChangeState <- constants.GameOverState
+			if l.generator != nil && l.pelletsRemaining == 0 && !l.outOfTime() {
+				if err := l.nextMaze(); err != nil {
+					log.Fatal(err)
+				}
+				l.startRound()
+				break
+			}
+			l.finish()
 			break MainLoop
 		}
 	}
@@ -158,32 +498,73 @@ MainLoop:
 
 // Draw the entire level
 func (l *Level) Draw(screen *ebiten.Image) {
-	l.ctx.Maze.Draw(screen)
+	// The maze is only held while taking a snapshot, since its objects keep changing
+	l.ctx.MazeMutex.Lock()
+	renderer := l.renderer
+	renderer.Snapshot()
+	l.ctx.MazeMutex.Unlock()
+	renderer.Draw(screen)
 	var str string
 	var x, y int
 	str = fmt.Sprintf("Score: %05d", l.player.Score)
 	x = 50
 	y = constants.VerticalTiles*constants.TileSize + 60
+	clock, hasClock := l.clock()
+	if hasClock {
+		// Leave room below for the clock
+		y -= 20
+	}
+	text.Draw(screen, str, l.anchorCtx.FontFace, x, y, color.White)
+	str = fmt.Sprintf("Lives: %d", l.lives)
+	x = constants.HorizontalTiles*constants.TileSize - textWidth(l.anchorCtx.FontFace, str) - 50
 	text.Draw(screen, str, l.anchorCtx.FontFace, x, y, color.White)
+	if hasClock {
+		seconds := int(math.Ceil(clock))
+		str = fmt.Sprintf("Time: %d:%02d", seconds/60, seconds%60)
+		x = centeredX(l.anchorCtx.FontFace, str, constants.HorizontalTiles*constants.TileSize)
+		y += 45
+		text.Draw(screen, str, l.anchorCtx.FontFace, x, y, color.White)
+	}
 }
 
 // NewLevel given a valid level file
 func NewLevel(levelFile string, numEnemies int, anchorCtx *contexts.AnchorContext) (*Level, error) {
 	l := Level{
-		enemies:   make([]*models.Ghost, 0, numEnemies),
-		anchorCtx: anchorCtx,
+		number:        1,
+		numEnemies:    numEnemies,
+		timeLeft:      constants.TimeAttackDuration,
+		nextWaveIn:    constants.SurvivalWaveInterval,
+		nextPelletsIn: constants.PelletRespawnInterval,
+		lives:         anchorCtx.Difficulty.Lives,
+		enemies:       make([]*models.Ghost, 0, numEnemies),
+		anchorCtx:     anchorCtx,
 		ctx: &contexts.GameContext{
 			GhostBases: make(map[constants.GhostType]interfaces.Location),
 			Msg: &structures.MessageBroker{
 				EatPellet:          make(chan bool),
-				PhaseChange:        make(chan int),
+				EatGhost:           make(chan struct{}),
+				PelletsRespawned:   make(chan uint, 1),
 				PowerPelletWoreOff: make(chan struct{}),
 				RemoveEnemies:      make(chan struct{}),
+				PacmanDied:         make(chan struct{}),
 				EndGame:            make(chan struct{}),
 			},
 		},
 	}
+	l.ctx.Running, l.stopRunning = context.WithCancel(context.Background())
 	l.ctx.SoundPlayer = anchorCtx.SoundPlayer
-	err := l.parseLevel(levelFile, numEnemies)
+	l.ctx.Difficulty = anchorCtx.Difficulty
+	l.ctx.Settings = anchorCtx.Difficulty.ForLevel(l.number)
+	l.ctx.Modes = modules.InitModeScheduler(l.ctx.Settings.ModeDurations)
+	l.ctx.Director = modules.InitDirector(anchorCtx.Adaptive)
+	if anchorCtx.Mode == constants.EndlessMode || anchorCtx.Mode == constants.TimeAttackMode {
+		l.generator = modules.InitMazeGenerator(time.Now().UnixNano())
+	}
+
+	lines, err := modules.LoadMaze(anchorCtx.Assets, levelFile)
+	if err != nil {
+		return &l, err
+	}
+	err = l.parseLevel(strings.NewReader(strings.Join(lines, "\n")))
 	return &l, err
 }
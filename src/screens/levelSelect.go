@@ -0,0 +1,177 @@
+package screens
+
+import (
+	"fmt"
+	"image/color"
+	"log"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/utils"
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+)
+
+// Size of every tile in the thumbnails of the levels
+const thumbnailTileSize = 12
+
+// levelEntry that can be selected
+type levelEntry struct {
+	file      string
+	name      string
+	thumbnail *ebiten.Image
+}
+
+// LevelSelect represents the screen to choose the level and the number of enemies
+type LevelSelect struct {
+	w           int
+	h           int
+	anchorCtx   *contexts.AnchorContext
+	keepRunning bool
+	levels      []*levelEntry
+	selected    int
+}
+
+// renderThumbnail of a level grid with the sprites of the game
+func renderThumbnail(lines []string, am *modules.AssetManager) *ebiten.Image {
+	thumbnail := ebiten.NewImage(
+		constants.HorizontalTiles*thumbnailTileSize,
+		constants.VerticalTiles*thumbnailTileSize,
+	)
+	for y, line := range lines {
+		for x, tile := range line {
+			sprite := am.TileSprite(tile)
+			if sprite == nil {
+				continue
+			}
+
+			op := &ebiten.DrawImageOptions{}
+			width, height := sprite.Size()
+			op.GeoM.Scale(thumbnailTileSize/float64(width), thumbnailTileSize/float64(height))
+			op.GeoM.Translate(float64(x*thumbnailTileSize), float64(y*thumbnailTileSize))
+			thumbnail.DrawImage(sprite, op)
+		}
+	}
+	return thumbnail
+}
+
+// loadLevel entry, skipping levels that cannot be played
+func (l *LevelSelect) loadLevel(file string) {
+	lines, err := modules.LoadMaze(l.anchorCtx.Assets, file)
+	if err == nil {
+		err = modules.ValidateLevel(lines)
+	}
+	if err != nil {
+		log.Printf("Skipping level %s: %v", file, err)
+		return
+	}
+
+	name := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	name = strings.NewReplacer("-", " ", "_", " ").Replace(name)
+	l.levels = append(l.levels, &levelEntry{
+		file:      file,
+		name:      strings.ToUpper(name),
+		thumbnail: renderThumbnail(lines, l.anchorCtx.AssetManager),
+	})
+	if filepath.Clean(file) == filepath.Clean(l.anchorCtx.LevelFile) {
+		l.selected = len(l.levels) - 1
+	}
+}
+
+// Run level select key listener
+func (l *LevelSelect) Run() {
+	// Keys still held from the previous screen are ignored until released
+	wasPressed := true
+	for l.keepRunning {
+		enterPressed := ebiten.IsKeyPressed(ebiten.KeyEnter)
+		leftPressed := ebiten.IsKeyPressed(ebiten.KeyLeft)
+		rightPressed := ebiten.IsKeyPressed(ebiten.KeyRight)
+		upPressed := ebiten.IsKeyPressed(ebiten.KeyUp)
+		downPressed := ebiten.IsKeyPressed(ebiten.KeyDown)
+		if ebiten.IsKeyPressed(ebiten.KeyEscape) {
+			l.keepRunning = false
+			l.anchorCtx.ChangeState <- constants.MenuState
+		} else if enterPressed && !wasPressed && len(l.levels) > 0 {
+			l.keepRunning = false
+			l.anchorCtx.LevelFile = l.levels[l.selected].file
+			l.anchorCtx.ChangeState <- constants.PlayState
+		} else if leftPressed && !wasPressed && len(l.levels) > 0 {
+			l.selected = utils.Mod(l.selected-1, len(l.levels))
+		} else if rightPressed && !wasPressed && len(l.levels) > 0 {
+			l.selected = utils.Mod(l.selected+1, len(l.levels))
+		} else if upPressed && !wasPressed && l.anchorCtx.NumEnemies < constants.MaxGhostsAllowed {
+			l.anchorCtx.NumEnemies++
+		} else if downPressed && !wasPressed && l.anchorCtx.NumEnemies > 1 {
+			l.anchorCtx.NumEnemies--
+		}
+		wasPressed = enterPressed || leftPressed || rightPressed || upPressed || downPressed
+		time.Sleep(time.Duration(10) * time.Millisecond)
+	}
+}
+
+// Draw the level select screen
+func (l *LevelSelect) Draw(screen *ebiten.Image) {
+	str := "SELECT A LEVEL"
+	x := centeredX(l.anchorCtx.FontFace, str, l.w)
+	y := 80
+	text.Draw(screen, str, l.anchorCtx.FontFace, x, y, color.White)
+
+	if len(l.levels) == 0 {
+		str = "NO LEVELS FOUND"
+		x = centeredX(l.anchorCtx.FontFace, str, l.w)
+		text.Draw(screen, str, l.anchorCtx.FontFace, x, l.h/2, color.White)
+		return
+	}
+
+	level := l.levels[l.selected]
+	op := &ebiten.DrawImageOptions{}
+	width, height := level.thumbnail.Size()
+	op.GeoM.Scale(1.5, 1.5)
+	op.GeoM.Translate(float64(l.w)/2-float64(width)*0.75, float64(y+40))
+	screen.DrawImage(level.thumbnail, op)
+
+	str = fmt.Sprintf("< %s >", level.name)
+	x = centeredX(l.anchorCtx.FontFace, str, l.w)
+	y += 40 + int(float64(height)*1.5) + 70
+	text.Draw(screen, str, l.anchorCtx.FontFace, x, y, color.White)
+	str = fmt.Sprintf("ENEMIES: %d", l.anchorCtx.NumEnemies)
+	x = centeredX(l.anchorCtx.FontFace, str, l.w)
+	y += 60
+	text.Draw(screen, str, l.anchorCtx.FontFace, x, y, color.White)
+	str = "PRESS ENTER TO PLAY"
+	x = centeredX(l.anchorCtx.FontFace, str, l.w)
+	y += 80
+	text.Draw(screen, str, l.anchorCtx.FontFace, x, y, color.White)
+}
+
+// NewLevelSelect screen with the levels found in the levels directory
+func NewLevelSelect(w, h int, anchorCtx *contexts.AnchorContext) *LevelSelect {
+	levelSelect := &LevelSelect{
+		w:           w,
+		h:           h,
+		anchorCtx:   anchorCtx,
+		keepRunning: true,
+		levels:      make([]*levelEntry, 0),
+	}
+
+	files, err := modules.ListLevels(anchorCtx.Assets, constants.LevelsDir)
+	if err != nil {
+		log.Println(err)
+	}
+	// Levels given from the command line are listed even if they are somewhere else
+	found := false
+	for _, file := range files {
+		found = found || filepath.Clean(file) == filepath.Clean(anchorCtx.LevelFile)
+	}
+	if !found {
+		files = append([]string{anchorCtx.LevelFile}, files...)
+	}
+	for _, file := range files {
+		levelSelect.loadLevel(file)
+	}
+	return levelSelect
+}
@@ -2,7 +2,6 @@ package screens
 
 import (
 	"image/color"
-	"time"
 
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/structures"
@@ -18,13 +17,8 @@ type Loading struct {
 	sprite    *structures.SpriteSequence
 }
 
-// Run loading screen logic
-func (l *Loading) Run() {
-	for {
-		l.sprite.Advance()
-		time.Sleep(time.Duration(100) * time.Millisecond)
-	}
-}
+// Run does nothing, since the sprite animates itself over time
+func (l *Loading) Run() {}
 
 // Draw the loading screen
 func (l *Loading) Draw(screen *ebiten.Image) {
@@ -38,23 +32,17 @@ func (l *Loading) Draw(screen *ebiten.Image) {
 		screen.DrawImage(frame, op)
 	}
 	str := "LOADING..."
-	x := (l.w - len(str)*30) / 2
+	x := centeredX(l.anchorCtx.FontFace, str, l.w)
 	y := (l.h+30)/2 + int(pacmanSize)*2
 	text.Draw(screen, str, l.anchorCtx.FontFace, x, y, color.White)
 }
 
 // NewLoading screen
 func NewLoading(w, h int, anchorCtx *contexts.AnchorContext) *Loading {
-	sprites := []string{
-		"assets/pacman/pacman-1.png",
-		"assets/pacman/pacman-2.png",
-		"assets/pacman/pacman-3.png",
-	}
-	seq, _ := structures.InitSpriteSequence(sprites)
 	return &Loading{
 		w:         w,
 		h:         h,
 		anchorCtx: anchorCtx,
-		sprite:    seq,
+		sprite:    anchorCtx.AssetManager.PacmanSprites["alive"].Clone(),
 	}
 }
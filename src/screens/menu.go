@@ -1,14 +1,17 @@
 package screens
 
 import (
+	"context"
+	"fmt"
 	"image/color"
+	"strings"
 	"time"
 
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/contexts"
-	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/modules"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/utils"
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 )
 
@@ -18,18 +21,54 @@ type Menu struct {
 	h           int
 	anchorCtx   *contexts.AnchorContext
 	keepRunning bool
-	mainTheme   *modules.InfiniteAudio
+	mainTheme   interfaces.LoopingSound
 }
 
-var menuScreen *ebiten.Image
+// selectDifficulty next to the current one in the given direction
+func (m *Menu) selectDifficulty(offset int) {
+	difficulties := m.anchorCtx.Difficulties
+	for i, difficulty := range difficulties {
+		if difficulty == m.anchorCtx.Difficulty {
+			m.anchorCtx.Difficulty = difficulties[utils.Mod(i+offset, len(difficulties))]
+			return
+		}
+	}
+}
+
+// selectMode next to the current one in the given direction
+func (m *Menu) selectMode(offset int) {
+	for i, mode := range constants.GameModes {
+		if mode == m.anchorCtx.Mode {
+			m.anchorCtx.Mode = constants.GameModes[utils.Mod(i+offset, len(constants.GameModes))]
+			return
+		}
+	}
+}
 
 // Run menu key listener
 func (m *Menu) Run() {
+	wasPressed := false
 	for m.keepRunning {
+		leftPressed := ebiten.IsKeyPressed(ebiten.KeyLeft)
+		rightPressed := ebiten.IsKeyPressed(ebiten.KeyRight)
+		upPressed := ebiten.IsKeyPressed(ebiten.KeyUp)
+		downPressed := ebiten.IsKeyPressed(ebiten.KeyDown)
 		if ebiten.IsKeyPressed(ebiten.KeyEnter) {
 			m.keepRunning = false
-			m.anchorCtx.ChangeState <- constants.PlayState
+			m.anchorCtx.ChangeState <- constants.LevelSelectState
+		} else if ebiten.IsKeyPressed(ebiten.KeyE) {
+			m.keepRunning = false
+			m.anchorCtx.ChangeState <- constants.EditorState
+		} else if leftPressed && !wasPressed {
+			m.selectDifficulty(-1)
+		} else if rightPressed && !wasPressed {
+			m.selectDifficulty(1)
+		} else if upPressed && !wasPressed {
+			m.selectMode(-1)
+		} else if downPressed && !wasPressed {
+			m.selectMode(1)
 		}
+		wasPressed = leftPressed || rightPressed || upPressed || downPressed
 		time.Sleep(time.Duration(10) * time.Millisecond)
 	}
 	m.mainTheme.Stop()
@@ -37,26 +76,37 @@ func (m *Menu) Run() {
 
 // Draw the menu screen
 func (m *Menu) Draw(screen *ebiten.Image) {
-	if menuScreen != nil {
+	if menuScreen := m.anchorCtx.AssetManager.MenuScreen; menuScreen != nil {
 		op := &ebiten.DrawImageOptions{}
 		_, h := menuScreen.Size()
 		op.GeoM.Translate(0, float64(m.h)/3-float64(h)/2)
 		screen.DrawImage(menuScreen, op)
 	}
 	str := "PRESS ENTER TO START"
-	x := (m.w - len(str)*30) / 2
+	x := centeredX(m.anchorCtx.FontFace, str, m.w)
 	y := (m.h+30)/2 + 100
 	text.Draw(screen, str, m.anchorCtx.FontFace, x, y, color.White)
+	str = fmt.Sprintf("< %s >", strings.ToUpper(m.anchorCtx.Difficulty.Name))
+	x = centeredX(m.anchorCtx.FontFace, str, m.w)
+	y += 60
+	text.Draw(screen, str, m.anchorCtx.FontFace, x, y, color.White)
+	str = fmt.Sprintf("MODE: %s", strings.ToUpper(constants.GameModeNames[m.anchorCtx.Mode]))
+	x = centeredX(m.anchorCtx.FontFace, str, m.w)
+	y += 60
+	text.Draw(screen, str, m.anchorCtx.FontFace, x, y, color.White)
+	str = "PRESS E TO EDIT A LEVEL"
+	x = centeredX(m.anchorCtx.FontFace, str, m.w)
+	y += 60
+	text.Draw(screen, str, m.anchorCtx.FontFace, x, y, color.White)
 }
 
 // NewMenu screen
 func NewMenu(w, h int, anchorCtx *contexts.AnchorContext) *Menu {
-	menuScreen, _, _ = ebitenutil.NewImageFromFile("assets/menu-screen.jpg")
 	return &Menu{
 		w:           w,
 		h:           h,
 		anchorCtx:   anchorCtx,
 		keepRunning: true,
-		mainTheme:   anchorCtx.SoundPlayer.PlayOnLoop(constants.MainTheme),
+		mainTheme:   anchorCtx.SoundPlayer.PlayOnLoop(context.Background(), constants.MainTheme),
 	}
 }
@@ -0,0 +1,16 @@
+package screens
+
+import (
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font"
+)
+
+// textWidth of a string as drawn with the given font
+func textWidth(face font.Face, str string) int {
+	return text.BoundString(face, str).Dx()
+}
+
+// centeredX where a string must be drawn to be centered within the given width
+func centeredX(face font.Face, str string, width int) int {
+	return (width - textWidth(face, str)) / 2
+}
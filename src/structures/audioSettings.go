@@ -0,0 +1,25 @@
+package structures
+
+import "github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+
+// AudioSettings of the mixer, kept across runs
+type AudioSettings struct {
+	Master    float64                        `json:"master"`
+	Buses     map[constants.AudioBus]float64 `json:"buses"`
+	Muted     bool                           `json:"muted"`
+	SoundPack string                         `json:"soundPack"`
+}
+
+// InitAudioSettings with every bus at full volume
+func InitAudioSettings() *AudioSettings {
+	return &AudioSettings{
+		Master: 1,
+		Buses: map[constants.AudioBus]float64{
+			constants.MusicBus: 1,
+			constants.SirenBus: 1,
+			constants.SFXBus:   1,
+		},
+		Muted:     false,
+		SoundPack: constants.DefaultSoundPack,
+	}
+}
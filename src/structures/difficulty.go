@@ -0,0 +1,43 @@
+package structures
+
+import "github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+
+// LevelSettings of a difficulty that apply from a given level onwards
+type LevelSettings struct {
+	FromLevel           int                         `json:"fromLevel"`
+	Speeds              constants.SpeedProfile      `json:"speeds"`
+	ModeDurations       []float64                   `json:"modeDurations"`
+	PowerPelletDuration float64                     `json:"powerPelletDuration"`
+	FlickeringDuration  float64                     `json:"flickeringDuration"`
+	ElroyPellets        [2]uint                     `json:"elroyPellets"`
+	GhostPelletLimits   map[constants.GhostType]int `json:"ghostPelletLimits"`
+	ReleaseTimeout      float64                     `json:"releaseTimeout"`
+}
+
+// AISettings that tweak the chase behavior of the ghosts
+type AISettings struct {
+	PinkyLookAhead   int     `json:"pinkyLookAhead"`
+	InkyLookBehind   int     `json:"inkyLookBehind"`
+	ClydeShyDistance float64 `json:"clydeShyDistance"`
+	RandomTurnChance float64 `json:"randomTurnChance"`
+}
+
+// Difficulty preset with every tuning parameter of the game
+type Difficulty struct {
+	Name               string          `json:"name"`
+	Lives              int             `json:"lives"`
+	GlobalPelletLimits []int           `json:"globalPelletLimits"`
+	AI                 AISettings      `json:"ai"`
+	Levels             []LevelSettings `json:"levels"`
+}
+
+// ForLevel settings given the level number. Levels are sorted by the level they start applying from
+func (d *Difficulty) ForLevel(level int) *LevelSettings {
+	settings := &d.Levels[0]
+	for i := range d.Levels {
+		if d.Levels[i].FromLevel <= level {
+			settings = &d.Levels[i]
+		}
+	}
+	return settings
+}
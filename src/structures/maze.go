@@ -4,11 +4,22 @@ import (
 	"errors"
 	"log"
 
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/interfaces"
 	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/utils"
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+// SpriteSnapshot of a game object, taken to draw it once the maze is released
+type SpriteSnapshot struct {
+	Sprite    *ebiten.Image
+	X         int
+	Y         int
+	Direction constants.Direction
+	Rotates   bool
+	Layer     int
+}
+
 // Maze represents the level map/maze
 type Maze struct {
 	rows     int
@@ -55,17 +66,35 @@ func (m *Maze) ElementsAt(x, y int) []interfaces.GameObject {
 	return m.logicMap[y][x].GetObjects()
 }
 
-// Draw the complete maze to the screen
-func (m *Maze) Draw(screen *ebiten.Image) {
+// Snapshot the sprites that change in the maze, which must be called while holding its mutex.
+// The pellet of every tile is kept in the given grid, while PacMan and the ghosts are appended
+// to the entities sorted by layer within each tile. Walls and bars never change so they are skipped
+func (m *Maze) Snapshot(pellets [][]*ebiten.Image, entities []SpriteSnapshot) []SpriteSnapshot {
 	for i := 0; i < m.rows; i++ {
 		for j := 0; j < m.cols; j++ {
-			objects := m.logicMap[i][j].GetObjects()
-			for k := range objects {
-				object := objects[len(objects)-1-k]
-				object.Draw(screen, j, i)
+			pellets[i][j] = nil
+			start := len(entities)
+			for _, object := range m.logicMap[i][j].elements {
+				switch layer := object.GetLayerIndex(); layer {
+				case constants.PelletLayerIdx:
+					pellets[i][j] = object.GetSprite()
+				case constants.FleeingGhostLayerIdx, constants.PacmanLayerIdx, constants.GhostLayerIdx:
+					entities = append(entities, SpriteSnapshot{
+						Sprite:    object.GetSprite(),
+						X:         j,
+						Y:         i,
+						Direction: object.GetDirection(),
+						Rotates:   object.IsMatrixEditable(),
+						Layer:     layer,
+					})
+					for k := len(entities) - 1; k > start && entities[k-1].Layer > entities[k].Layer; k-- {
+						entities[k-1], entities[k] = entities[k], entities[k-1]
+					}
+				}
 			}
 		}
 	}
+	return entities
 }
 
 // AddElement to the maze
@@ -3,8 +3,10 @@ package structures
 // MessageBroker that can send and receive messages through channels
 type MessageBroker struct {
 	EatPellet          chan bool
+	EatGhost           chan struct{}
+	PelletsRespawned   chan uint
 	PowerPelletWoreOff chan struct{}
-	PhaseChange        chan int
 	RemoveEnemies      chan struct{}
+	PacmanDied         chan struct{}
 	EndGame            chan struct{}
 }
@@ -0,0 +1,21 @@
+package structures
+
+import "github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+
+// Motion accumulates the distance travelled by a movable object between tiles
+type Motion struct {
+	distance float64
+}
+
+// Tick the motion at the given speed percentage and return the number of tiles to advance
+func (m *Motion) Tick(speed float64) int {
+	m.distance += constants.BaseSpeed * speed / 100
+	steps := int(m.distance / constants.TileSize)
+	m.distance -= float64(steps) * constants.TileSize
+	return steps
+}
+
+// InitMotion of a movable object
+func InitMotion() *Motion {
+	return &Motion{distance: 0}
+}
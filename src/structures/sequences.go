@@ -1,44 +1,102 @@
 package structures
 
 import (
+	"sync"
+	"time"
+
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
-// SpriteSequence represents a sequence of animation frames
+// SpriteSequence represents an animation whose current frame depends on the time since it started,
+// so it runs at the same pace no matter how often its object moves
 type SpriteSequence struct {
-	current int
-	frames  []*ebiten.Image
+	mutex     sync.Mutex
+	frames    []*ebiten.Image
+	durations []time.Duration
+	total     time.Duration
+	loop      bool
+	start     time.Time
+	pausedAt  time.Time
 }
 
-// Advance current frame and indicate whether it was the last frame
-func (s *SpriteSequence) Advance() bool {
-	isLast := s.current+1 == len(s.frames)
-	s.current = (s.current + 1) % len(s.frames)
-	return isLast
+// elapsed time of the animation, which must be called while holding the mutex
+func (s *SpriteSequence) elapsed() time.Duration {
+	if !s.pausedAt.IsZero() {
+		return s.pausedAt.Sub(s.start)
+	}
+	return time.Since(s.start)
 }
 
-// GetCurrentFrame to be used by an animator
-func (s *SpriteSequence) GetCurrentFrame() *ebiten.Image {
-	return s.frames[s.current]
+// Reset the animation to its first frame
+func (s *SpriteSequence) Reset() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.start = time.Now()
+	if !s.pausedAt.IsZero() {
+		s.pausedAt = s.start
+	}
 }
 
-// InitSpriteSequence instantiates a sprite sequence
-func InitSpriteSequence(sprites []string) (*SpriteSequence, error) {
-	seq := SpriteSequence{
-		current: 0,
-		frames:  make([]*ebiten.Image, 0, len(sprites)),
+// Pause the animation in its current frame
+func (s *SpriteSequence) Pause() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.pausedAt.IsZero() {
+		s.pausedAt = time.Now()
+	}
+}
+
+// Resume the animation from the frame it was paused in
+func (s *SpriteSequence) Resume() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.pausedAt.IsZero() {
+		s.start = s.start.Add(time.Since(s.pausedAt))
+		s.pausedAt = time.Time{}
 	}
+}
 
-	for _, sprite := range sprites {
-		img, _, err := ebitenutil.NewImageFromFile(sprite)
-		if err != nil {
-			return nil, err
+// Finished animation, which never happens if it loops
+func (s *SpriteSequence) Finished() bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return !s.loop && s.elapsed() >= s.total
+}
+
+// GetCurrentFrame to be used by an animator
+func (s *SpriteSequence) GetCurrentFrame() *ebiten.Image {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	elapsed := s.elapsed()
+	if s.loop && s.total > 0 {
+		elapsed %= s.total
+	}
+	for i, duration := range s.durations {
+		if elapsed < duration {
+			return s.frames[i]
 		}
-		seq.frames = append(seq.frames, img)
+		elapsed -= duration
 	}
+	return s.frames[len(s.frames)-1]
+}
 
-	return &seq, nil
+// Clone the animation starting from its first frame, sharing the images
+func (s *SpriteSequence) Clone() *SpriteSequence {
+	return InitSpriteSequence(s.frames, s.durations, s.loop)
+}
+
+// InitSpriteSequence instantiates an animation with the duration of every frame
+func InitSpriteSequence(frames []*ebiten.Image, durations []time.Duration, loop bool) *SpriteSequence {
+	seq := SpriteSequence{
+		frames:    frames,
+		durations: durations,
+		loop:      loop,
+		start:     time.Now(),
+	}
+	for _, duration := range durations {
+		seq.total += duration
+	}
+	return &seq
 }
 
 // SoundSequence represents a sequence of audio files
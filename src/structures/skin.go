@@ -0,0 +1,38 @@
+package structures
+
+// SpriteDefinition of the animation of a sprite. Frames are either listed or numbered from 1 to
+// Count following a pattern, and each one is the name of a frame of the atlas of the skin or the
+// path to an image. Every frame lasts the given milliseconds, unless Durations sets each one
+type SpriteDefinition struct {
+	Frames    []string `json:"frames"`
+	Pattern   string   `json:"pattern"`
+	Count     int      `json:"count"`
+	Duration  int      `json:"duration"`
+	Durations []int    `json:"durations"`
+	Loop      bool     `json:"loop"`
+}
+
+// SkinManifest with the look of the game, with paths relative to the manifest
+type SkinManifest struct {
+	Name       string                       `json:"name"`
+	Background string                       `json:"background"`
+	WallColor  string                       `json:"wallColor"`
+	Atlas      string                       `json:"atlas"`
+	Font       string                       `json:"font"`
+	FontSize   float64                      `json:"fontSize"`
+	Sprites    map[string]*SpriteDefinition `json:"sprites"`
+}
+
+// FrameRect of a frame in the image of a sprite atlas
+type FrameRect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// SpriteAtlas with every frame packed in a single image, relative to the atlas file
+type SpriteAtlas struct {
+	Image  string               `json:"image"`
+	Frames map[string]FrameRect `json:"frames"`
+}
@@ -0,0 +1,14 @@
+package structures
+
+import (
+	"time"
+
+	"github.com/LuisPalominoTrevilla/MultithreadedPacman/src/constants"
+)
+
+// SoundEvent of a sound effect, with the time elapsed since the recording started
+type SoundEvent struct {
+	Effect constants.SoundEffect
+	Action constants.SoundAction
+	At     time.Duration
+}
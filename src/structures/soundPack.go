@@ -0,0 +1,7 @@
+package structures
+
+// SoundPackManifest with the files of every sound effect of a pack, relative to the manifest
+type SoundPackManifest struct {
+	Name   string              `json:"name"`
+	Sounds map[string][]string `json:"sounds"`
+}
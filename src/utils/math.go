@@ -1,5 +1,7 @@
 package utils
 
+import "math"
+
 // Mod of a number, the Python way
 func Mod(d, m int) int {
 	var res int = d % m
@@ -8,3 +10,36 @@ func Mod(d, m int) int {
 	}
 	return res
 }
+
+// Abs value of an integer
+func Abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Sign of an integer, either -1, 0 or 1
+func Sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Min of two integers
+func Min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Clamp a value between a lower and an upper bound
+func Clamp(value, lower, upper float64) float64 {
+	return math.Max(lower, math.Min(upper, value))
+}